@@ -0,0 +1,111 @@
+// Command arena plays matches between two computer players and prints their score,
+// to compare engines and their settings:
+//
+//	go run ./cmd/arena -a mcts:iterations=2000,policy=tactical -b mcts:iterations=2000 -games 100
+//
+// The players are described as accepted by player.Parse. They swap sides after each game, O playing first.
+//
+// With -calibrate, each difficulty profile of player.DefaultProfiles plays the match against player.ReferenceSpec,
+// giving the scores of the profiles:
+//
+//	go run ./cmd/arena -calibrate -games 200
+package main
+
+import (
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+// score counts the results of a match from the point of view of the first player
+type score struct {
+	wins, losses, draws int
+}
+
+// points gives 1 per win and 1/2 per draw
+func (s score) points() float64 {
+	return float64(s.wins) + float64(s.draws)/2
+}
+
+func (s score) String() string {
+	games := s.wins + s.losses + s.draws
+	return fmt.Sprintf("+%d -%d =%d, %.1f/%d (%.1f%%)",
+		s.wins, s.losses, s.draws, s.points(), games, 100*s.points()/float64(max(games, 1)))
+}
+
+// playMatch plays the games between the players of the specifications a and b, a playing O in the even games.
+// The players are created for each game with the seed seed+game, so each game is reproducible.
+func playMatch(ctx context.Context, a, b string, games int, seed int64, report func(game int, result score)) (score, error) {
+	var total score
+	for game := 0; game < games; game++ {
+		playerA, err := player.Parse(a, seed+int64(game))
+		if err != nil {
+			return total, err
+		}
+		playerB, err := player.Parse(b, seed+int64(game))
+		if err != nil {
+			return total, err
+		}
+		sideA, playerO, playerX := engine.PLAYER1, playerA, playerB
+		if game%2 == 1 {
+			sideA, playerO, playerX = engine.PLAYER2, playerB, playerA
+		}
+
+		winner, err := player.PlayGame(ctx, engine.NewGame(engine.PLAYER1), playerO, playerX)
+		if err != nil {
+			return total, fmt.Errorf("game %d: %w", game+1, err)
+		}
+		var result score
+		switch winner {
+		case sideA:
+			result.wins++
+		case sideA.Opponent():
+			result.losses++
+		default:
+			result.draws++
+		}
+		total.wins += result.wins
+		total.losses += result.losses
+		total.draws += result.draws
+		if report != nil {
+			report(game, result)
+		}
+	}
+	return total, nil
+}
+
+func main() {
+	a := flag.String("a", "mcts:iterations=2000", "first player")
+	b := flag.String("b", "random", "second player")
+	games := flag.Int("games", 10, "number of games, the players swap sides after each game")
+	seed := flag.Int64("seed", 1, "seed of the first game")
+	verbose := flag.Bool("v", false, "print the result of each game")
+	calibrate := flag.Bool("calibrate", false, "play every difficulty profile against the reference player")
+	flag.Parse()
+
+	if *calibrate {
+		for _, profile := range player.DefaultProfiles() {
+			runMatch("mcts:profile="+profile.Name, player.ReferenceSpec, *games, *seed, *verbose)
+		}
+		return
+	}
+	runMatch(*a, *b, *games, *seed, *verbose)
+}
+
+// runMatch plays the match and prints its score, it exits on error
+func runMatch(a, b string, games int, seed int64, verbose bool) {
+	total, err := playMatch(context.Background(), a, b, games, seed, func(game int, result score) {
+		if verbose {
+			fmt.Printf("game %d: %v\n", game+1, result)
+		}
+	})
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s vs %s: %v\n", a, b, total)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPlayMatch(t *testing.T) {
+	games := 0
+	total, err := playMatch(context.Background(), "mcts:iterations=1000", "random", 4, 1, func(int, score) {
+		games++
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if games != 4 || total.wins != 4 {
+		t.Errorf("Expected MCTS to win the 4 games against random, got %v", total)
+	}
+	if total.String() != "+4 -0 =0, 4.0/4 (100.0%)" {
+		t.Errorf("Unexpected score %q", total)
+	}
+}
+
+func TestPlayMatchInvalidPlayer(t *testing.T) {
+	if _, err := playMatch(context.Background(), "mcts", "unknown", 2, 1, nil); err == nil {
+		t.Errorf("Expected an unknown player to be refused")
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+const (
+	analysisStep           = 5000   // simulations between two refinements of the analysis
+	analysisMaxSimulations = 500000 // bounds the memory taken by the tree of the analysis
+)
+
+// analysis is the evaluation of every legal move of the position shown, refined by a background search
+type analysis struct {
+	enabled     bool                         // true when the evaluations are shown
+	position    string                       // position searched, empty when no search runs
+	cancel      context.CancelFunc           // stops the search while it runs
+	results     chan analysisResult          // receives the refinements of the search while it runs
+	moves       map[engine.Move]ai.MoveStats // evaluations of the moves of the position
+	simulations int                          // simulations of the evaluations
+}
+
+// analysisResult is a refinement of the evaluations sent to the game loop
+type analysisResult struct {
+	moves       []ai.MoveStats
+	simulations int
+}
+
+// toggleAnalysis shows or hides the evaluations of the moves
+func (g *Game) toggleAnalysis() {
+	g.analysis.enabled = !g.analysis.enabled
+	if !g.analysis.enabled {
+		g.stopAnalysis()
+	}
+}
+
+// updateAnalysis keeps the search of the analysis on the position shown, it is called on every tick.
+// The search pauses while the AI is thinking, so they do not share the processors.
+func (g *Game) updateAnalysis() {
+	if !g.analysis.enabled {
+		return
+	}
+	position := g.Game.String()
+	if g.AIRunning || g.IsOver() || (g.state != Playing && g.state != Replaying) {
+		g.stopAnalysis()
+		return
+	}
+	if position != g.analysis.position {
+		g.stopAnalysis()
+		g.startAnalysis(position)
+	}
+	g.receiveAnalysis()
+}
+
+// startAnalysis searches the position until it changes, sending the evaluations after each step
+func (g *Game) startAnalysis(position string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// each search has its own channel, so the evaluations of another position are never shown
+	results := make(chan analysisResult, 1)
+	g.analysis.position = position
+	g.analysis.cancel = cancel
+	g.analysis.results = results
+	go func(game *engine.Game) {
+		searcher := ai.NewSearcher(ai.Config{
+			Iterations: analysisStep,
+			Seed:       time.Now().UnixNano(),
+			Workers:    runtime.NumCPU(),
+		})
+		// the searcher continues its tree at each step, the position being the same
+		for simulations := 0; simulations < analysisMaxSimulations && ctx.Err() == nil; {
+			result := searcher.Search(ctx, game)
+			simulations += result.Simulations
+			// only the last evaluations are kept
+			select {
+			case <-results:
+			default:
+			}
+			results <- analysisResult{moves: searcher.Moves(), simulations: simulations}
+			// once the outcome of the position is proven, the searcher has nothing left to simulate
+			if result.Simulations == 0 || result.Solved {
+				return
+			}
+		}
+	}(g.Game.Clone())
+}
+
+// receiveAnalysis shows the last evaluations of the search
+func (g *Game) receiveAnalysis() {
+	select {
+	case result := <-g.analysis.results:
+		g.analysis.moves = make(map[engine.Move]ai.MoveStats, len(result.moves))
+		for _, move := range result.moves {
+			g.analysis.moves[move.Move] = move
+		}
+		g.analysis.simulations = result.simulations
+	default:
+	}
+}
+
+// stopAnalysis stops the search of the analysis and forgets its evaluations
+func (g *Game) stopAnalysis() {
+	if g.analysis.cancel != nil {
+		g.analysis.cancel()
+	}
+	g.analysis.position = ""
+	g.analysis.cancel = nil
+	g.analysis.results = nil
+	g.analysis.moves = nil
+	g.analysis.simulations = 0
+}
+
+// analysisInformation describes the search of the analysis
+func (g *Game) analysisInformation() string {
+	if g.analysis.position == "" {
+		return "Analysis paused, I: hide"
+	}
+	return fmt.Sprintf("Analysis of %v: %d simulations, I: hide", g.Playing(), g.analysis.simulations)
+}
+
+// evaluationLabel is the label of a move in the analysis: its outcome when proven, its win probability otherwise
+func evaluationLabel(move ai.MoveStats) string {
+	if move.Outcome != ai.Draw {
+		return move.Outcome.String()
+	}
+	return fmt.Sprintf("%.0f%%", move.WinProbability*100)
+}
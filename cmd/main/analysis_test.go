@@ -0,0 +1,110 @@
+package main
+
+import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestAnalysis(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.AIEnabled = false
+	game.setupPlayers()
+	game.state = Playing
+	game.toggleAnalysis()
+
+	// waitEvaluations runs the game loop until the evaluations of the position are received
+	waitEvaluations := func() {
+		for deadline := time.Now().Add(5 * time.Second); len(game.analysis.moves) == 0 && time.Now().Before(deadline); {
+			time.Sleep(10 * time.Millisecond)
+			game.updateAnalysis()
+		}
+	}
+	waitEvaluations()
+	if len(game.analysis.moves) != len(game.PossibleMoves()) || game.analysis.simulations == 0 {
+		t.Fatalf("Expected an evaluation of the %d moves, got %d", len(game.PossibleMoves()), len(game.analysis.moves))
+	}
+	for _, move := range game.PossibleMoves() {
+		if stats, ok := game.analysis.moves[move]; !ok || stats.Visits == 0 {
+			t.Errorf("Expected an evaluation of %v", move)
+		}
+	}
+
+	if err := game.makePlay(game.PossibleMoves()[0]); err != nil {
+		t.Fatal(err)
+	}
+	game.updateAnalysis()
+	if game.analysis.position != game.Game.String() {
+		t.Fatalf("Expected the analysis to follow the position")
+	}
+	waitEvaluations()
+	for move := range game.analysis.moves {
+		if err := game.Clone().Play(move); err != nil {
+			t.Errorf("Expected the evaluations of the new position, got %v: %v", move, err)
+		}
+	}
+
+	game.toggleAnalysis()
+	if game.analysis.cancel != nil || game.analysis.moves != nil {
+		t.Errorf("Expected the analysis to stop when hidden")
+	}
+}
+
+func TestAnalysisStopsWhenProven(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.AIEnabled = false
+	game.setupPlayers()
+	game.state = Playing
+	// plays random moves until the player to move can win the game
+	rng := rand.New(rand.NewSource(1))
+	for !game.IsOver() && !canWin(game.Game) {
+		moves := game.PossibleMoves()
+		if err := game.makePlay(moves[rng.Intn(len(moves))]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if game.IsOver() {
+		t.Fatalf("Expected a winning move before the end of the game")
+	}
+	game.toggleAnalysis()
+
+	proven := func() bool {
+		for _, move := range game.analysis.moves {
+			if move.Outcome == ai.Win {
+				return true
+			}
+		}
+		return false
+	}
+	for deadline := time.Now().Add(5 * time.Second); !proven() && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		game.updateAnalysis()
+	}
+	if !proven() {
+		t.Fatalf("Expected the winning move to be proven, got %+v", game.analysis.moves)
+	}
+	time.Sleep(50 * time.Millisecond)
+	game.receiveAnalysis()
+	time.Sleep(100 * time.Millisecond)
+	if len(game.analysis.results) != 0 {
+		t.Errorf("Expected the search to stop once the position is proven")
+	}
+	game.toggleAnalysis()
+}
+
+// canWin tells if a move of the player to move wins the game
+func canWin(game *engine.Game) bool {
+	for _, move := range game.PossibleMoves() {
+		next := game.Clone()
+		if next.Play(move) == nil && next.Winner() == game.Playing() {
+			return true
+		}
+	}
+	return false
+}
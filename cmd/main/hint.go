@@ -0,0 +1,127 @@
+package main
+
+import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+const (
+	defaultHintBudget = 3                      // hints per game, unless given on the command line
+	hintDuration      = 500 * time.Millisecond // thinking time of the search of a hint
+)
+
+// hint is the move suggested to the human by a short search.
+// The search has its own tree, so the AI opponent keeps its tree and its statistics.
+type hint struct {
+	budget  int                                  // hints per game, unlimited when negative
+	used    int                                  // hints shown in the current game
+	move    engine.Move                          // suggested move in the current position
+	targets [BoardRowLength][BoardRowLength]bool // mini-boards the opponent would be sent to by the move
+	shown   bool                                 // true when the move is shown
+	running bool                                 // true while the search of a hint runs
+	cancel  context.CancelFunc                   // cancels the search while running
+	results chan engine.Move                     // receives the move of the search while running
+}
+
+// hintsLeft returns the number of hints the human can still ask in the game, negative when unlimited
+func (g *Game) hintsLeft() int {
+	if g.hint.budget < 0 {
+		return -1
+	}
+	return max(g.hint.budget-g.hint.used, 0)
+}
+
+// askHint starts the search of a hint for the human to move, if the budget of the game allows it.
+// The move is shown by receiveHint on the game loop, the hint is only charged to the budget then.
+func (g *Game) askHint() {
+	if g.hint.running || g.hint.shown || g.hintsLeft() == 0 ||
+		g.spectator.enabled || g.state != Playing || g.AIRunning || !g.isHumanTurn() {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	// each search has its own channel, so the move of a cancelled search is never shown
+	results := make(chan engine.Move, 1)
+	g.hint.cancel = cancel
+	g.hint.results = results
+	g.hint.running = true
+	go func(game *engine.Game) {
+		// the search of MonteCarloMove, cancelled when the position changes
+		result := ai.Search(ctx, game, ai.Config{
+			Duration:    hintDuration,
+			Seed:        time.Now().UnixNano(),
+			Workers:     runtime.NumCPU(),
+			SolverCells: ai.DefaultSolverCells,
+		})
+		results <- result.Move
+	}(g.Game.Clone())
+}
+
+// receiveHint shows the move of the hint once its search is over
+func (g *Game) receiveHint() {
+	if !g.hint.running {
+		return
+	}
+	select {
+	case move := <-g.hint.results:
+		g.hint.cancel()
+		g.hint.cancel = nil
+		g.hint.results = nil
+		g.hint.running = false
+		g.hint.move = move
+		g.hint.shown = move != engine.NoMove
+		if g.hint.shown {
+			g.hint.used++
+			g.hint.targets = hintTargets(g.Game, move)
+		}
+	default:
+	}
+}
+
+// clearHint hides the hint and stops its search, when the position changes
+func (g *Game) clearHint() {
+	if g.hint.running {
+		g.hint.cancel()
+		g.hint.cancel = nil
+		g.hint.results = nil
+		g.hint.running = false
+	}
+	g.hint.shown = false
+}
+
+// resetHints gives back the budget of hints for a new game
+func (g *Game) resetHints() {
+	g.clearHint()
+	g.hint.used = 0
+}
+
+// hintTargets tells which open mini-boards the opponent would be sent to by the move of the hint
+func hintTargets(game *engine.Game, move engine.Move) [BoardRowLength][BoardRowLength]bool {
+	var targets [BoardRowLength][BoardRowLength]bool
+	next := game.Clone()
+	if next.Play(move) != nil || next.IsOver() {
+		return targets
+	}
+	for i := range targets {
+		for j := range targets[i] {
+			targets[i][j] = next.IsValidPlay(i, j) && next.MiniBoardWinner(i, j) == engine.EMPTY
+		}
+	}
+	return targets
+}
+
+// hintInformation describes the hint shown or the hints left
+func (g *Game) hintInformation() string {
+	switch {
+	case g.hint.running:
+		return "Hint: thinking..."
+	case g.hint.shown:
+		return fmt.Sprintf("Hint: %v", g.hint.move)
+	case g.hintsLeft() < 0:
+		return "H: hint"
+	}
+	return fmt.Sprintf("H: hint (%d left)", g.hintsLeft())
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// waitHint runs the game loop until the search of the hint is over
+func waitHint(t *testing.T, game *Game) {
+	for deadline := time.Now().Add(5 * time.Second); game.hint.running && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		game.receiveHint()
+	}
+	if game.hint.running {
+		t.Fatalf("Expected the search of the hint to end")
+	}
+}
+
+func TestHint(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.hint.budget = 1
+	game.Game = engine.NewGame(engine.PLAYER1)
+	game.AISide = engine.PLAYER2
+	game.setupPlayers()
+	game.state = Playing
+
+	game.askHint()
+	waitHint(t, game)
+	if !game.hint.shown || game.hintsLeft() != 0 {
+		t.Fatalf("Expected a hint to be shown, %d left", game.hintsLeft())
+	}
+	if err := game.Clone().Play(game.hint.move); err != nil {
+		t.Errorf("Expected a legal hint, got %v", err)
+	}
+	if !game.hint.targets[game.hint.move.MiniBoardRow][game.hint.move.MiniBoardCol] {
+		t.Errorf("Expected the hint %v to send the opponent to its mini-board", game.hint.move)
+	}
+	if stats := game.players[engine.PLAYER2].(*player.MCTS).LastStats(); !reflect.DeepEqual(stats, player.Stats{}) {
+		t.Errorf("Expected the AI opponent not to search for the hint, got %+v", stats)
+	}
+
+	if err := game.makePlay(game.hint.move); err != nil {
+		t.Fatal(err)
+	}
+	if game.hint.shown {
+		t.Errorf("Expected the hint to be hidden once a move is played")
+	}
+	if err := game.makePlay(game.PossibleMoves()[0]); err != nil {
+		t.Fatal(err)
+	}
+	game.askHint()
+	if game.hint.running {
+		t.Errorf("Expected no hint beyond the budget of the game")
+	}
+	game.Load()
+	if game.hintsLeft() != 1 {
+		t.Errorf("Expected the budget to be given back in a new game, got %d", game.hintsLeft())
+	}
+}
+
+func TestHintCancelledByUndo(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.hint.budget = 1
+	game.AIEnabled = false
+	game.setupPlayers()
+	game.state = Playing
+	if err := game.makePlay(game.PossibleMoves()[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	game.askHint()
+	if !game.hint.running {
+		t.Fatalf("Expected the search of a hint")
+	}
+	game.undo()
+	game.receiveHint()
+	if game.hint.running || game.hint.shown {
+		t.Errorf("Expected the hint to be cancelled when the position is taken back")
+	}
+	if game.hintsLeft() != 1 {
+		t.Errorf("Expected a cancelled hint not to be charged, %d left", game.hintsLeft())
+	}
+
+	game.hint.budget = -1
+	game.askHint()
+	if !game.hint.running || game.hintsLeft() >= 0 {
+		t.Errorf("Expected an unlimited budget of hints")
+	}
+	game.clearHint()
+}
+
+func TestHintTargets(t *testing.T) {
+	// O won the first mini-board, a move sending there lets the opponent play in any open mini-board
+	game, err := engine.ParsePosition("OOO....XX/X......../........./........./........./........./........./........./......... X - O........")
+	if err != nil {
+		t.Fatal(err)
+	}
+	targets := hintTargets(game, engine.Move{MainBoardRow: 0, MainBoardCol: 2, MiniBoardRow: 0, MiniBoardCol: 0})
+	for i := range targets {
+		for j := range targets[i] {
+			if targets[i][j] != (i != 0 || j != 0) {
+				t.Errorf("Expected only the open mini-boards as targets, got %v for mini-board (%d, %d)", targets[i][j], i, j)
+			}
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"GoTicTacToe/lib/engine"
 	"GoTicTacToe/lib/graphics"
+	"GoTicTacToe/lib/player"
+	"flag"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
@@ -16,11 +19,13 @@ import (
 const (
 	WindowWidth    = 800
 	WindowHeight   = 900
+	PanelWidth     = 260                      // width of the side panel of the search, right of the board
+	ScreenWidth    = WindowWidth + PanelWidth // width of the board and the side panel
 	FontSize       = 15
 	BigFontSize    = 100
 	DPI            = 72
 	NbPlayer       = 2
-	BoardRowLength = 3
+	BoardRowLength = engine.BoardRowLength
 )
 
 const (
@@ -28,14 +33,7 @@ const (
 	Playing
 	PlayAgain
 	WaitingForGameStart
-)
-
-// enum determining the symbols contained in the game
-const (
-	PLAYER1 GameSymbol = 'O'
-	PLAYER2 GameSymbol = 'X'
-	EMPTY   GameSymbol = ' ' // for empty cell
-	NONE    GameSymbol = 0
+	Replaying
 )
 
 var (
@@ -46,36 +44,26 @@ var (
 	gameGraphics = graphics.Init(WindowWidth)
 )
 
-// get the symbol of the cell at the given coordinates
-func (g *Game) getValueOfCoordinates(coordinates graphics.BoardCoord) GameSymbol {
-	return g.gameBoard[coordinates.MainBoardRow][coordinates.MainBoardCol].Board[coordinates.MiniBoardRow][coordinates.MiniBoardCol]
-}
-
-// set the symbol of the cell at the given coordinates
-func (g *Game) setValueOfCoordinates(coordinates graphics.BoardCoord, value GameSymbol) {
-	g.gameBoard[coordinates.MainBoardRow][coordinates.MainBoardCol].
-		Board[coordinates.MiniBoardRow][coordinates.MiniBoardCol] = value
-}
-
 // get the coordinates of the cell clicked by the player in a mini tic-tac-toe board
-func (g *Game) getMiniBoardCoordinates(mouseX, mouseY int) graphics.BoardCoord {
+func (g *Game) getMiniBoardCoordinates(mouseX, mouseY int) engine.Move {
 	miniTicTacToeSize := WindowWidth / BoardRowLength           // size of a whole mini tic-tac-toe board
 	miniTicTacToeCellSize := miniTicTacToeSize / BoardRowLength // size of a cell in a mini tic-tac-toe board
 
 	// get clicked cell coordinates
 	// MAIN BOARD
-	mainRow := mouseX / miniTicTacToeSize // the index of the row clicked
-	mainCol := mouseY / miniTicTacToeSize // the index of the column clicked
+	// rows go down the screen (mouse Y) and columns go right (mouse X)
+	mainRow := mouseY / miniTicTacToeSize // the index of the row clicked
+	mainCol := mouseX / miniTicTacToeSize // the index of the column clicked
 
 	// get normalized coordinates
-	normalizedX := mouseX - mainRow*miniTicTacToeSize
-	normalizedY := mouseY - mainCol*miniTicTacToeSize
+	normalizedX := mouseX - mainCol*miniTicTacToeSize
+	normalizedY := mouseY - mainRow*miniTicTacToeSize
 
 	// MINI BOARD
-	miniRow := normalizedX / miniTicTacToeCellSize // the index of the row clicked
-	miniCol := normalizedY / miniTicTacToeCellSize // the index of the column clicked
+	miniRow := normalizedY / miniTicTacToeCellSize // the index of the row clicked
+	miniCol := normalizedX / miniTicTacToeCellSize // the index of the column clicked
 
-	return graphics.BoardCoord{
+	return engine.Move{
 		MainBoardRow: mainRow,
 		MainBoardCol: mainCol,
 		MiniBoardRow: miniRow,
@@ -86,6 +74,14 @@ func (g *Game) getMiniBoardCoordinates(mouseX, mouseY int) graphics.BoardCoord {
 // Update : game life cycle method called at "game tic" and apply the game logic depending on the current state.
 // It is called by the ebiten engine.
 func (g *Game) Update() error {
+	if ebiten.IsWindowBeingClosed() {
+		g.stopComputerMove()
+		g.autosave()
+		return ebiten.Termination
+	}
+	g.receiveComputerMove()
+	g.receiveHint()
+
 	switch g.state {
 	case Init:
 		// called at the beginning of the game
@@ -94,91 +90,133 @@ func (g *Game) Update() error {
 		// At this point, the player is configuring the game parameters
 		// before starting the game by clicking on the space bar
 		if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+			g.setupPlayers()
 			g.state = Playing
 		}
-		for i := ebiten.Key1; i <= ebiten.Key5; i++ {
-			if inpututil.IsKeyJustPressed(i) {
-				g.AIDifficulty = float64(i - ebiten.Key1 + 1)
-				break
-			}
+		if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+			// the score of a match is kept apart from the games of the player
+			g.spectator.enabled = !g.spectator.enabled
+			g.ResetPoints()
+		}
+		if g.spectator.enabled {
+			g.updateSpectatorSettings()
+			return nil
+		}
+		if profile, ok := g.pressedProfile(); ok {
+			g.AIProfile = profile
 		}
 		if inpututil.IsKeyJustPressed(ebiten.KeyA) {
 			g.AIEnabled = !g.AIEnabled
 		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+			g.AIEngine = (g.AIEngine + 1) % engineCount
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyS) && !isControlPressed() {
+			g.AISide = g.AISide.Opponent()
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyP) && !isControlPressed() {
+			g.AIPondering = !g.AIPondering
+		}
+		if g.canResume && inpututil.IsKeyJustPressed(ebiten.KeyC) {
+			g.canResume = false
+			g.setStatus("Game resumed", g.resume())
+		}
+		if g.canReplay && inpututil.IsKeyJustPressed(ebiten.KeyV) {
+			path, err := sessionPath(lastGameFileName)
+			if err == nil {
+				err = g.loadReplay(path)
+			}
+			g.setStatus("", err)
+		}
 	case Playing:
 		// At this point, the game is running and a player can make a move
-
-		// if it is the AI's turn, we wait for it to finish
-		// the AI is running in a goroutine
-		if g.AIRunning {
-			return nil
+		if g.spectator.enabled {
+			g.updateSpectator()
 		}
 
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			mx, my := ebiten.CursorPosition()
-			if mx > WindowWidth || my > WindowWidth {
-				return nil
-			}
-			boardCoordinates := g.getMiniBoardCoordinates(mx, my)
-
-			if !g.isValidPlay(boardCoordinates.MainBoardRow, boardCoordinates.MainBoardCol) {
-				return nil
-			}
-			if g.getValueOfCoordinates(boardCoordinates) == EMPTY {
-				g.makePlay(boardCoordinates)
-			}
-		}
-		if g.AIEnabled && g.playing == PLAYER2 {
-			go func() {
-				g.AIRunning = true
-				bestMove, simulations, winProbability := g.MonteCarloMove()
-				g.AISimulations = simulations
-				g.AIWinProbability = winProbability
-				g.makePlay(bestMove)
-				g.AIRunning = false
-			}()
+		// while the AI is thinking in a goroutine, the moves wait for its search,
+		// the shortcuts below can still cancel it
+		if !g.AIRunning {
+			g.updateMoves()
 		}
 
+	case Replaying:
+		// At this point, a recorded game is shown move by move
+		g.updateReplay()
 	case PlayAgain:
-		// At the end of a game, the player can choose to play again (clicking by mouse)
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		// At the end of a game, the player can choose to play again (clicking by mouse),
+		// the next game of an AI versus AI match starts by itself
+		if g.spectator.enabled {
+			g.updateSpectator()
+		} else if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 			g.Load()
 		}
 	}
-	// at any time, the player can reset the game by pressing the R key or quit the game by pressing the escape key
+	// at any time, the player can show the analysis of the position with I, take back or replay moves
+	// with Ctrl+Z and Ctrl+Y, save or load the session with Ctrl+S and Ctrl+L,
+	// reset the game by pressing the R key or quit the game by pressing the escape key
+	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		g.toggleAnalysis()
+	}
+	g.updateAnalysis()
+	if isShortcutJustPressed(ebiten.KeyZ) {
+		g.undo()
+	}
+	if isShortcutJustPressed(ebiten.KeyY) {
+		g.redo()
+	}
+	if isShortcutJustPressed(ebiten.KeyS) && g.canSave() {
+		g.setStatus("Game saved", g.save(saveFileName))
+	}
+	if isShortcutJustPressed(ebiten.KeyL) {
+		g.setStatus("Game loaded", g.load(saveFileName))
+	}
 
 	if inpututil.KeyPressDuration(ebiten.KeyR) == 60 {
 		g.Load()
 		g.ResetPoints()
 	}
 	if inpututil.KeyPressDuration(ebiten.KeyEscape) == 60 {
+		g.stopComputerMove()
+		g.autosave()
 		os.Exit(0)
 	}
 	return nil
 }
 
-// determine if the move is applicable
-func (g *Game) isValidPlay(row, col int) bool {
-	if g.lastPlay.MiniBoardRow == -1 {
-		// the first move of the game is always valid
-		return true
-	} else if g.gameBoard[g.lastPlay.MiniBoardRow][g.lastPlay.MiniBoardCol].Winner != EMPTY {
-		// when the last move complete a mini-game, the next move can be played anywhere
-		return true
-	} else if row == g.lastPlay.MiniBoardRow && col == g.lastPlay.MiniBoardCol {
-		// the next move must be played in the mini-game corresponding to the last move position
-		return true
+// updateMoves takes the move of the player whose turn it is: a click of the human or the search of the AI
+func (g *Game) updateMoves() {
+	// the AI can think while the human is choosing a move, and the human can ask for a hint
+	if g.isHumanTurn() {
+		g.startPondering()
+		if inpututil.IsKeyJustPressed(ebiten.KeyH) {
+			g.askHint()
+		}
+	}
+
+	if g.isHumanTurn() && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mx, my := ebiten.CursorPosition()
+		if mx > WindowWidth || my > WindowWidth {
+			return
+		}
+		// clicks on a cell that cannot be played are ignored
+		if err := g.makePlay(g.getMiniBoardCoordinates(mx, my)); err != nil {
+			return
+		}
+	}
+	// in the AI versus AI mode, the moves are spaced out so they can be followed
+	if !g.isHumanTurn() && g.state == Playing && (!g.spectator.enabled || g.waited(g.spectator.moveDelay)) {
+		g.playComputerMove()
 	}
-	return false
 }
 
-func (g *Game) DrawSymbol(boardCoord graphics.BoardCoord, symbol GameSymbol) {
+func (g *Game) DrawSymbol(boardCoord engine.Move, symbol engine.GameSymbol) {
 	symbolImage = g.getSymbolImage(symbol)
 
 	xPos, yPos := graphics.GetPositionOfSymbol(boardCoord)
 	opSymbol := &ebiten.DrawImageOptions{}
 	opSymbol.GeoM.Translate(xPos, yPos)
-	if g.lastPlay == boardCoord {
+	if g.LastMove() == boardCoord {
 		opSymbol.ColorScale.Scale(0.5, 0.5, 0.5, 1)
 	}
 	gameImage.DrawImage(symbolImage, opSymbol)
@@ -210,98 +248,51 @@ func (g *Game) init() {
 
 	re := newRandom().Intn(NbPlayer)
 	if re == 0 {
-		g.playing = PLAYER1
+		g.Game = engine.NewGame(engine.PLAYER1)
 	} else {
-		g.playing = PLAYER2
+		g.Game = engine.NewGame(engine.PLAYER2)
 	}
+	g.loadProfiles()
 	g.Load()
 	g.ResetPoints()
 	g.state = WaitingForGameStart
-	g.lastPlay = graphics.BoardCoord{MainBoardRow: -1, MainBoardCol: -1, MiniBoardRow: -1, MiniBoardCol: -1}
 	g.AIEnabled = true
+	g.AISide = engine.PLAYER2
+	g.spectator = newSpectator(g.AIProfile)
+	g.setupPlayers()
+	g.canResume = hasAutosave()
+	g.canReplay = hasLastGame()
+	if g.replayPath != "" {
+		g.setStatus("", g.loadReplay(g.replayPath))
+	}
 }
 
+// Load starts a new game, the player to move in the previous game plays first
 func (g *Game) Load() {
-	for i := 0; i < 3; i++ {
-		for j := 0; j < 3; j++ {
-			g.gameBoard[i][j] = MiniBoard{Board: [3][3]GameSymbol{
-				{EMPTY, EMPTY, EMPTY},
-				{EMPTY, EMPTY, EMPTY},
-				{EMPTY, EMPTY, EMPTY}},
-				Winner: EMPTY}
-		}
-	}
-	g.round = 0
-	g.win = EMPTY
-	g.lastPlay = graphics.BoardCoord{MainBoardRow: -1, MainBoardCol: -1, MiniBoardRow: -1, MiniBoardCol: -1}
-
-	// by default, the AI is set to the second difficulty level
-	if g.AIDifficulty == 0 {
-		g.AIDifficulty = 2
+	g.stopComputerMove()
+	g.stopPondering()
+	g.resetHints()
+	g.Game = engine.NewGame(g.Playing())
+
+	// by default, the AI plays at the medium difficulty level
+	if g.AIProfile.Name == "" {
+		g.AIProfile, _ = player.FindProfile(g.profiles, defaultProfileName)
 	}
 	g.state = WaitingForGameStart
 }
 
-func (g *Game) wins(winner GameSymbol) {
-	if winner == PLAYER1 {
-		g.win = PLAYER1
+func (g *Game) wins(winner engine.GameSymbol) {
+	if winner == engine.PLAYER1 {
 		g.pointsO++
 		g.state = PlayAgain
-	} else if winner == PLAYER2 {
-		g.win = PLAYER2
+	} else if winner == engine.PLAYER2 {
 		g.pointsX++
 		g.state = PlayAgain
-	} else if winner == NONE {
-		g.win = NONE
+	} else if winner == engine.NONE {
 		g.state = PlayAgain
 	}
 }
 
-func (g *Game) CheckWin() GameSymbol {
-	for i := 0; i < 3; i++ {
-		for j := 0; j < 3; j++ {
-			g.gameBoard[i][j].CheckWin()
-		}
-	}
-	for i := 0; i < 3; i++ {
-		if g.winnerOnLine(i, 0, 0, 1) != EMPTY {
-			return g.winnerOnLine(i, 0, 0, 1)
-		}
-	}
-	for i := 0; i < 3; i++ {
-		if g.winnerOnLine(0, i, 1, 0) != EMPTY {
-			return g.winnerOnLine(0, i, 1, 0)
-		}
-	}
-	if g.winnerOnLine(0, 0, 1, 1) != EMPTY {
-		return g.winnerOnLine(0, 0, 1, 1)
-	}
-	if g.winnerOnLine(0, 2, 1, -1) != EMPTY {
-		return g.winnerOnLine(0, 2, 1, -1)
-	}
-	for i := 0; i < 3; i++ {
-		for j := 0; j < 3; j++ {
-			if g.gameBoard[i][j].Winner == EMPTY {
-				return EMPTY
-			}
-		}
-	}
-
-	return NONE
-}
-
-// winnerOnLine checks if there is a winner on the given line
-// x, y: the starting point of the line
-// dx, dy: delta applied to x and y to get the next point on the line
-func (g *Game) winnerOnLine(x, y, dx, dy int) GameSymbol {
-	for i := 0; i < 3; i++ {
-		if g.gameBoard[x][y].Winner != g.gameBoard[x+dx*i][y+dy*i].Winner {
-			return EMPTY
-		}
-	}
-	return g.gameBoard[x][y].Winner
-}
-
 func (g *Game) ResetPoints() {
 	g.pointsO = 0
 	g.pointsX = 0
@@ -312,35 +303,96 @@ func newRandom() *rand.Rand {
 	return rand.New(s1)
 }
 func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
-	return WindowWidth, WindowHeight
+	return ScreenWidth, WindowHeight
 }
 func main() {
 	game := &Game{}
-	ebiten.SetWindowSize(WindowWidth, WindowHeight)
+	flag.StringVar(&game.replayPath, "replay", "", "game record to replay")
+	flag.IntVar(&game.hint.budget, "hints", defaultHintBudget, "hints per game, unlimited when negative")
+	flag.StringVar(&game.profilesPath, "profiles", "", "file of difficulty profiles, "+profilesFileName+" of the save directory by default")
+	flag.Parse()
+	ebiten.SetWindowSize(ScreenWidth, WindowHeight)
 	ebiten.SetWindowTitle("TicTacToe")
+	ebiten.SetWindowClosingHandled(true)
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func (g *Game) switchPlayer() {
-	g.playing = g.getOpponents(g.playing)
+// apply a move to the current game and update the score when it ends the game
+func (g *Game) makePlay(move engine.Move) error {
+	if err := g.Play(move); err != nil {
+		return err
+	}
+	g.clearHint()
+	g.wins(g.Winner())
+	if g.IsOver() {
+		g.saveLastGame()
+		g.canReplay = true
+	}
+	return nil
+}
+
+// show the result of an action to the player, or its error
+func (g *Game) setStatus(success string, err error) {
+	if err != nil {
+		log.Println(err)
+		g.status = err.Error()
+	} else {
+		g.status = success
+	}
+}
+
+// take back the last move, in games against the AI the moves are taken back until it is a human's turn
+func (g *Game) undo() {
+	if g.spectator.enabled || (g.state != Playing && g.state != PlayAgain) || !g.CanUndo() {
+		return
+	}
+	// the move the AI is searching would be played in the wrong position
+	g.stopComputerMove()
+	// the point of a finished game is given back
+	if g.Winner() == engine.PLAYER1 {
+		g.pointsO--
+	} else if g.Winner() == engine.PLAYER2 {
+		g.pointsX--
+	}
+	g.stopPondering()
+	g.clearHint()
+	for g.Undo() {
+		if g.isHumanTurn() {
+			break
+		}
+	}
+	g.state = Playing
 }
-func (g *Game) getOpponents(playerJustMoved GameSymbol) GameSymbol {
-	if playerJustMoved == PLAYER1 {
-		return PLAYER2
+
+// replay the moves taken back, in games against the AI the moves are replayed until it is a human's turn
+func (g *Game) redo() {
+	if g.AIRunning || g.spectator.enabled || g.state != Playing || !g.CanRedo() {
+		return
+	}
+	g.stopPondering()
+	g.clearHint()
+	for g.Redo() {
+		if g.isHumanTurn() {
+			break
+		}
 	}
-	return PLAYER1
+	g.wins(g.Winner())
 }
-func (g *Game) makePlay(move graphics.BoardCoord) {
-	g.setValueOfCoordinates(move, g.playing)
-	g.wins(g.CheckWin())
-	g.round++
-	g.lastPlay = move
-	g.switchPlayer()
+
+// isShortcutJustPressed checks if the key has just been pressed while holding Control (or Command on macOS)
+func isShortcutJustPressed(key ebiten.Key) bool {
+	return isControlPressed() && inpututil.IsKeyJustPressed(key)
 }
-func (g *Game) getSymbolImage(player GameSymbol) *ebiten.Image {
-	if player == PLAYER1 {
+
+// isControlPressed checks if Control (or Command on macOS) is held
+func isControlPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+}
+
+func (g *Game) getSymbolImage(player engine.GameSymbol) *ebiten.Image {
+	if player == engine.PLAYER1 {
 		return gameGraphics.Circle
 	}
 	return gameGraphics.Cross
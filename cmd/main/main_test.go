@@ -1,29 +1,34 @@
 package main
 
 import (
-	"GoTicTacToe/lib/graphics"
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestGameInit(t *testing.T) {
+	useTempConfigDir(t)
 	game := &Game{}
 
 	game.init()
 
-	if game.playing != PLAYER1 && game.playing != PLAYER2 {
-		t.Errorf("Unexpected player: %v", game.playing)
+	if game.Playing() != engine.PLAYER1 && game.Playing() != engine.PLAYER2 {
+		t.Errorf("Unexpected player: %v", game.Playing())
 	}
 
 	if game.state != WaitingForGameStart {
 		t.Errorf("Unexpected state: %v", game.state)
 	}
 
-	if game.round != 0 {
-		t.Errorf("Unexpected round: %d", game.round)
+	if game.Round() != 0 {
+		t.Errorf("Unexpected round: %d", game.Round())
 	}
 
-	if game.win != EMPTY {
-		t.Errorf("Unexpected win: %v", game.win)
+	if game.Winner() != engine.EMPTY {
+		t.Errorf("Unexpected win: %v", game.Winner())
 	}
 
 	if game.AIEnabled != true {
@@ -31,62 +36,169 @@ func TestGameInit(t *testing.T) {
 	}
 }
 
-func TestIsValidPlay(t *testing.T) {
+func TestMakePlayUpdatesScore(t *testing.T) {
+	useTempConfigDir(t)
 	game := &Game{}
 	game.init()
+	game.state = Playing
 
-	// Test when lastPlay is -1
-	if !game.isValidPlay(0, 0) {
-		t.Errorf("Expected true, got false")
+	for game.state == Playing {
+		if err := game.makePlay(game.PossibleMoves()[0]); err != nil {
+			t.Fatal(err)
+		}
 	}
 
-	// Test when lastPlay is not -1
-	game.lastPlay = graphics.BoardCoord{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 0, MiniBoardCol: 0}
-	if !game.isValidPlay(0, 0) {
-		t.Errorf("Expected true, got false")
+	if game.Winner() == engine.PLAYER1 && game.pointsO != 1 {
+		t.Errorf("Expected 1 point for O, got %d", game.pointsO)
 	}
-
-	if game.isValidPlay(1, 1) {
-		t.Errorf("Expected false, got true")
+	if game.Winner() == engine.PLAYER2 && game.pointsX != 1 {
+		t.Errorf("Expected 1 point for X, got %d", game.pointsX)
+	}
+	if game.state != PlayAgain {
+		t.Errorf("Unexpected state: %v", game.state)
 	}
 }
 
-func TestGetValueOfCoordinates(t *testing.T) {
+func TestUndoAgainstAI(t *testing.T) {
+	useTempConfigDir(t)
 	game := &Game{}
 	game.init()
+	game.Game = engine.NewGame(engine.PLAYER1)
+	game.state = Playing
+
+	for i := 0; i < 2; i++ {
+		if err := game.makePlay(game.PossibleMoves()[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	game.undo()
+
+	if game.Round() != 0 || game.Playing() != engine.PLAYER1 {
+		t.Errorf("Expected both moves to be taken back, round %d", game.Round())
+	}
 
-	coordinates := graphics.BoardCoord{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 0, MiniBoardCol: 0}
-	game.setValueOfCoordinates(coordinates, PLAYER1)
+	game.redo()
+	if game.Round() != 2 || game.Playing() != engine.PLAYER1 {
+		t.Errorf("Expected both moves to be replayed, round %d", game.Round())
+	}
+}
 
-	if game.getValueOfCoordinates(coordinates) != PLAYER1 {
-		t.Errorf("Expected %v, got %v", PLAYER1, game.getValueOfCoordinates(coordinates))
+func TestPonderingDuringHumanTurn(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.Game = engine.NewGame(engine.PLAYER1)
+	game.state = Playing
+	game.startPondering()
+	if game.cancelPondering != nil {
+		t.Errorf("Expected no pondering when it is disabled")
+	}
+
+	game.AIPondering = true
+	game.startPondering()
+	if game.cancelPondering == nil {
+		t.Fatalf("Expected the AI to ponder during the turn of the human")
+	}
+	if err := game.makePlay(game.PossibleMoves()[0]); err != nil {
+		t.Fatal(err)
+	}
+	game.undo()
+	if game.cancelPondering != nil {
+		t.Errorf("Expected the pondering to stop when the position is taken back")
 	}
 }
 
-func TestSetValueOfCoordinates(t *testing.T) {
+// startComputerMove starts a game where the AI plays first with a short thinking time
+func startComputerMove(t *testing.T) *Game {
+	useTempConfigDir(t)
 	game := &Game{}
 	game.init()
+	game.Game = engine.NewGame(engine.PLAYER2)
+	game.AIProfile = player.Profile{Name: "fast", Config: ai.Config{Duration: 50 * time.Millisecond}}
+	game.setupPlayers()
+	game.state = Playing
+	game.playComputerMove()
+	if !game.AIRunning {
+		t.Fatalf("Expected the AI to be searching")
+	}
+	return game
+}
 
-	coordinates := graphics.BoardCoord{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 0, MiniBoardCol: 0}
-	game.setValueOfCoordinates(coordinates, PLAYER1)
+func TestComputerMoveOnGameLoop(t *testing.T) {
+	game := startComputerMove(t)
+	for deadline := time.Now().Add(5 * time.Second); game.AIRunning && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		game.receiveComputerMove()
+	}
+	if game.AIRunning || game.Round() != 1 || len(game.AIStats.PrincipalVariation) == 0 {
+		t.Errorf("Expected the move of the AI to be played, round %d", game.Round())
+	}
+}
 
-	if game.gameBoard[0][0].Board[0][0] != PLAYER1 {
-		t.Errorf("Expected %v, got %v", PLAYER1, game.gameBoard[0][0].Board[0][0])
+func TestResetCancelsComputerMove(t *testing.T) {
+	game := startComputerMove(t)
+	results := game.aiResults
+	game.Load()
+	if game.AIRunning {
+		t.Errorf("Expected the search to be cancelled")
+	}
+	<-results
+	game.receiveComputerMove()
+	if game.Round() != 0 {
+		t.Errorf("Expected the move of the cancelled search to be dropped, round %d", game.Round())
+	}
+}
+
+func TestUndoCancelsComputerMove(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.Game = engine.NewGame(engine.PLAYER1)
+	game.AIProfile = player.Profile{Name: "fast", Config: ai.Config{Duration: 50 * time.Millisecond}}
+	game.setupPlayers()
+	game.state = Playing
+	if err := game.makePlay(game.PossibleMoves()[0]); err != nil {
+		t.Fatal(err)
+	}
+	game.playComputerMove()
+	results := game.aiResults
+	game.undo()
+	if game.AIRunning || game.Round() != 0 {
+		t.Errorf("Expected the search to be cancelled and the move of the human taken back, round %d", game.Round())
+	}
+	<-results
+	game.receiveComputerMove()
+	if game.Round() != 0 {
+		t.Errorf("Expected the move of the cancelled search to be dropped, round %d", game.Round())
 	}
 }
 
-func TestCheckWin(t *testing.T) {
+func TestMakePlayRefusesInvalidMove(t *testing.T) {
+	useTempConfigDir(t)
 	game := &Game{}
 	game.init()
+	game.state = Playing
 
-	// Set up a winning condition for PLAYER1
-	for i := 0; i < 3; i++ {
-		for j := 0; j < 3; j++ {
-			game.gameBoard[i][j].Winner = PLAYER1
-		}
+	move := engine.Move{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 1, MiniBoardCol: 1}
+	if err := game.makePlay(move); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.makePlay(move); !errors.Is(err, engine.ErrWrongBoard) {
+		t.Errorf("Expected %v, got %v", engine.ErrWrongBoard, err)
 	}
+}
+
+func TestGetMiniBoardCoordinates(t *testing.T) {
+	game := &Game{}
+	cellSize := WindowWidth / BoardRowLength / BoardRowLength
 
-	if game.CheckWin() != PLAYER1 {
-		t.Errorf("Expected %v, got %v", PLAYER1, game.CheckWin())
+	// a click in the top right corner of the window is in the top row
+	move := game.getMiniBoardCoordinates(8*cellSize+cellSize/2, cellSize/2)
+	if move.String() != "c3" {
+		t.Errorf("Expected c3, got %v", move)
+	}
+	move = game.getMiniBoardCoordinates(cellSize/2, 5*cellSize+cellSize/2)
+	if move.String() != "d7" {
+		t.Errorf("Expected d7, got %v", move)
 	}
 }
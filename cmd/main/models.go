@@ -1,23 +1,37 @@
 package main
 
-import "GoTicTacToe/lib/graphics"
+import (
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"context"
+)
 
-// GameSymbol determine the symbols contained in the game
-type GameSymbol rune
 type GameState int
 
 type Game struct {
-	playing          GameSymbol                                // current player as a symbol
-	state            GameState                                 // current state of the game
-	gameBoard        [BoardRowLength][BoardRowLength]MiniBoard // the game board
-	round            int                                       // current round index
-	pointsO          int                                       // points of player 1
-	pointsX          int                                       // points of player 2
-	win              GameSymbol                                // winner symbol or EMPTY if the game has not ended yet
-	lastPlay         graphics.BoardCoord                       // last play coordinates
-	AISimulations    int                                       // number of simulations done by the AI
-	AIWinProbability float64                                   // probability of winning for the AI
-	AIRunning        bool                                      // true if the AI is processing a move
-	AIDifficulty     float64                                   // difficulty level of the AI
-	AIEnabled        bool                                      // true if the AI is enabled
+	*engine.Game                                        // rules and position of the current game
+	state           GameState                           // current state of the game
+	pointsO         int                                 // points of player 1
+	pointsX         int                                 // points of player 2
+	AIStats         player.Stats                        // statistics of the last search of the AI
+	AIRunning       bool                                // true if the AI is processing a move
+	cancelAI        context.CancelFunc                  // cancels the search of the AI while AIRunning
+	aiResults       chan computerMove                   // receives the move of the AI while AIRunning
+	AIProfile       player.Profile                      // difficulty level of the AI
+	profiles        []player.Profile                    // difficulty levels selected with the keys 1 to 9
+	profilesPath    string                              // profiles file given on the command line
+	AIEngine        int                                 // engine of the AI, one of the engine constants
+	AIEnabled       bool                                // true if the AI is enabled
+	AISide          engine.GameSymbol                   // side played by the AI
+	AIPondering     bool                                // true if the AI thinks during the turn of the human
+	cancelPondering context.CancelFunc                  // stops the pondering of the AI, nil when it is not pondering
+	players         map[engine.GameSymbol]player.Player // players of the current game, humans play with the mouse
+	canResume       bool                                // true if the session saved when leaving can be resumed
+	status          string                              // result of the last save or load
+	canReplay       bool                                // true if the record of the last finished game can be replayed
+	replayPath      string                              // game record to replay at start, given on the command line
+	spectator       spectator                           // settings of the AI versus AI mode
+	replay          replay                              // settings of the replay viewer
+	hint            hint                                // move suggested to the human
+	analysis        analysis                            // evaluations of the moves of the position shown
 }
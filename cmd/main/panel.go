@@ -0,0 +1,41 @@
+package main
+
+import (
+	"GoTicTacToe/lib/player"
+	"fmt"
+	"strings"
+)
+
+const panelLineMoves = 5 // moves of the principal variation on each line of the side panel
+
+// searchReport describes the last search of the AI in the side panel: its speed and depth,
+// the line it expects and the moves it hesitated between
+func searchReport(stats player.Stats) string {
+	if stats.Simulations == 0 && len(stats.PrincipalVariation) == 0 {
+		return "Search of the AI\n\nNo search yet"
+	}
+	var report strings.Builder
+	fmt.Fprintf(&report, "Search of the AI\n\n")
+	fmt.Fprintf(&report, "Simulations: %d\n", stats.Simulations)
+	fmt.Fprintf(&report, "Nodes per second: %.0f\n", stats.NodesPerSecond)
+	fmt.Fprintf(&report, "Tree depth: %d plies\n", stats.Depth)
+	fmt.Fprintf(&report, "Win confidence: %.0f%%\n\n", stats.WinProbability*100)
+	report.WriteString("Expected line:\n")
+	for i, move := range stats.PrincipalVariation {
+		switch {
+		case i == 0:
+		case i%panelLineMoves == 0:
+			report.WriteString("\n")
+		default:
+			report.WriteString(" ")
+		}
+		fmt.Fprintf(&report, "%v", move)
+	}
+	if len(stats.Candidates) > 0 {
+		report.WriteString("\n\nCandidates:\n")
+		for _, move := range stats.Candidates {
+			fmt.Fprintf(&report, "%v  %d visits  %s\n", move.Move, move.Visits, evaluationLabel(move))
+		}
+	}
+	return report.String()
+}
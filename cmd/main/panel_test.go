@@ -0,0 +1,34 @@
+package main
+
+import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"strings"
+	"testing"
+)
+
+func TestSearchReport(t *testing.T) {
+	if report := searchReport(player.Stats{}); !strings.Contains(report, "No search yet") {
+		t.Errorf("Expected no report before the first search, got %q", report)
+	}
+	var line []engine.Move
+	for _, notation := range []string{"e5", "e1", "a5", "e2", "b5", "e3", "c5"} {
+		move, _ := engine.ParseMove(notation)
+		line = append(line, move)
+	}
+	report := searchReport(player.Stats{
+		Simulations:        1000,
+		WinProbability:     0.5,
+		PrincipalVariation: line,
+		Candidates:         []ai.MoveStats{{Move: line[0], Visits: 600, WinProbability: 0.55}, {Move: line[2], Visits: 50, Outcome: ai.Loss}},
+		Depth:              12,
+		NodesPerSecond:     20000,
+	})
+	for _, expected := range []string{"Simulations: 1000", "Nodes per second: 20000", "Tree depth: 12 plies",
+		"e5 e1 a5 e2 b5\ne3 c5", "e5  600 visits  55%", "a5  50 visits  " + ai.Loss.String()} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Expected %q in the report %q", expected, report)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"context"
+	"log"
+)
+
+// setupPlayers creates the players of the game from the settings of the start screen:
+// humans play with the mouse and the AI plays the side AISide when it is enabled,
+// both sides are played by the chosen engines in the AI versus AI mode
+func (g *Game) setupPlayers() {
+	g.stopComputerMove()
+	g.stopPondering()
+	if g.spectator.enabled {
+		g.players = map[engine.GameSymbol]player.Player{
+			engine.PLAYER1: g.spectator.settings[engine.PLAYER1].newPlayer(),
+			engine.PLAYER2: g.spectator.settings[engine.PLAYER2].newPlayer(),
+		}
+		return
+	}
+	g.players = map[engine.GameSymbol]player.Player{
+		engine.PLAYER1: player.NewHuman("Human"),
+		engine.PLAYER2: player.NewHuman("Human"),
+	}
+	if g.AIEnabled {
+		settings := aiSettings{engine: g.AIEngine, profile: g.AIProfile}
+		g.players[g.AISide] = settings.newPlayer()
+	}
+}
+
+// isHumanTurn tells if the next move is given with the mouse
+func (g *Game) isHumanTurn() bool {
+	_, human := g.players[g.Playing()].(*player.Human)
+	return human
+}
+
+// computerMove is the result of the search of a computer player, sent to the game loop
+type computerMove struct {
+	move  engine.Move
+	stats *player.Stats // statistics of the search, nil if the player gives none
+	err   error
+}
+
+// playComputerMove lets the computer player to move choose its move in a goroutine,
+// the move is played by receiveComputerMove on the game loop
+func (g *Game) playComputerMove() {
+	current := g.players[g.Playing()]
+	g.stopPondering()
+	ctx, cancel := context.WithCancel(context.Background())
+	// each search has its own channel, so the result of a cancelled search is never received
+	results := make(chan computerMove, 1)
+	g.cancelAI = cancel
+	g.aiResults = results
+	g.AIRunning = true
+	go func(game *engine.Game) {
+		var result computerMove
+		result.move, result.err = current.Move(ctx, game)
+		if stats, ok := current.(player.StatsReporter); ok {
+			lastStats := stats.LastStats()
+			result.stats = &lastStats
+		}
+		results <- result
+	}(g.Game.Clone())
+}
+
+// receiveComputerMove plays the move of the computer player once its search is over
+func (g *Game) receiveComputerMove() {
+	if !g.AIRunning {
+		return
+	}
+	select {
+	case result := <-g.aiResults:
+		g.cancelAI()
+		g.cancelAI = nil
+		g.aiResults = nil
+		g.AIRunning = false
+		if result.stats != nil {
+			g.AIStats = *result.stats
+		}
+		err := result.err
+		if err == nil {
+			err = g.makePlay(result.move)
+		}
+		if err != nil {
+			log.Println(err)
+		}
+	default:
+	}
+}
+
+// stopComputerMove cancels the search of the computer player, its move will not be played
+func (g *Game) stopComputerMove() {
+	if g.AIRunning {
+		g.cancelAI()
+		g.cancelAI = nil
+		g.aiResults = nil
+		g.AIRunning = false
+	}
+}
+
+// startPondering lets the AI search the position during the turn of the human when pondering is enabled,
+// until the human moves or the position changes
+func (g *Game) startPondering() {
+	if !g.AIPondering || !g.AIEnabled || g.spectator.enabled || g.cancelPondering != nil || g.IsOver() {
+		return
+	}
+	ponderer, ok := g.players[g.AISide].(player.Ponderer)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancelPondering = cancel
+	go ponderer.Ponder(ctx, g.Game.Clone())
+}
+
+// stopPondering stops the search started by startPondering, the next move of the AI waits for its end
+func (g *Game) stopPondering() {
+	if g.cancelPondering != nil {
+		g.cancelPondering()
+		g.cancelPondering = nil
+	}
+}
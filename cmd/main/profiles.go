@@ -0,0 +1,52 @@
+package main
+
+import (
+	"GoTicTacToe/lib/player"
+	"errors"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"io/fs"
+)
+
+const (
+	profilesFileName   = "profiles.json"
+	defaultProfileName = "medium"
+)
+
+// loadProfiles reads the difficulty levels of the profiles file given on the command line,
+// or of the profiles file of the save directory when it exists, the default ones are used otherwise
+func (g *Game) loadProfiles() {
+	g.profiles = player.DefaultProfiles()
+	path := g.profilesPath
+	if path == "" {
+		var err error
+		if path, err = sessionPath(profilesFileName); err != nil {
+			return
+		}
+	}
+	profiles, err := player.ReadProfiles(path)
+	if errors.Is(err, fs.ErrNotExist) && g.profilesPath == "" {
+		return
+	}
+	if err != nil {
+		g.setStatus("", err)
+		return
+	}
+	g.profiles = profiles
+}
+
+// pressedProfile returns the difficulty level whose key, 1 to 9, has just been pressed
+func (g *Game) pressedProfile() (player.Profile, bool) {
+	for i := 0; i < min(len(g.profiles), 9); i++ {
+		if inpututil.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(i)) {
+			return g.profiles[i], true
+		}
+	}
+	return player.Profile{}, false
+}
+
+// profileKeys describes the keys selecting the difficulty levels
+func (g *Game) profileKeys() string {
+	return fmt.Sprintf("1 to %d", min(len(g.profiles), 9))
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
 	"GoTicTacToe/lib/graphics"
 	"fmt"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -23,13 +25,14 @@ func (g *Game) Draw(screen *ebiten.Image) {
 func (g *Game) drawGameBoard(screen *ebiten.Image) {
 	for i := 0; i < 3; i++ {
 		for j := 0; j < 3; j++ {
-			if g.gameBoard[i][j].Winner == EMPTY {
+			if g.MiniBoardWinner(i, j) == engine.EMPTY {
 				g.drawMiniBoard(i, j, screen)
 			} else {
 				g.drawMiniBoardWinner(i, j, screen)
 			}
 		}
 	}
+	g.drawHint(screen)
 	gameImage.DrawImage(gameGraphics.MainBoard, nil)
 	screen.DrawImage(gameImage, nil)
 
@@ -41,27 +44,84 @@ func (g *Game) drawMiniBoardWinner(i, j int, screen *ebiten.Image) {
 
 	gameBoardImageOptions.GeoM.Reset()
 	gameBoardImageOptions.GeoM.Scale(3, 3)
-	gameBoardImageOptions.GeoM.Translate(float64(WindowWidth/3*i), float64(WindowWidth/3*j))
-	if g.gameBoard[i][j].Winner == PLAYER1 {
+	gameBoardImageOptions.GeoM.Translate(float64(WindowWidth/3*j), float64(WindowWidth/3*i))
+	if g.MiniBoardWinner(i, j) == engine.PLAYER1 {
 		screen.DrawImage(gameGraphics.Circle, gameBoardImageOptions)
 	} else {
 		screen.DrawImage(gameGraphics.Cross, gameBoardImageOptions)
 	}
 }
+
+// colors of the cell of the hint and of the mini-boards it sends the opponent to
+var (
+	hintCellColor   = color.NRGBA{R: 255, G: 215, A: 110}
+	hintTargetColor = color.NRGBA{R: 255, G: 140, A: 35}
+)
+
+// drawHint highlights the cell of the hint and the mini-boards where the opponent would play next
+func (g *Game) drawHint(screen *ebiten.Image) {
+	if !g.hint.shown {
+		return
+	}
+	for i := range g.hint.targets {
+		for j := range g.hint.targets[i] {
+			if g.hint.targets[i][j] {
+				options := &ebiten.DrawImageOptions{}
+				options.GeoM.Scale(3, 3)
+				options.GeoM.Translate(float64(WindowWidth/3*j), float64(WindowWidth/3*i))
+				options.ColorScale.ScaleWithColor(hintTargetColor)
+				screen.DrawImage(gameGraphics.Highlight, options)
+			}
+		}
+	}
+	x, y := graphics.GetPositionOfSymbol(g.hint.move)
+	options := &ebiten.DrawImageOptions{}
+	options.GeoM.Translate(x, y)
+	options.ColorScale.ScaleWithColor(hintCellColor)
+	screen.DrawImage(gameGraphics.Highlight, options)
+}
+
+// drawEvaluation colors the cell from red to green with the win probability of its move in the analysis,
+// and writes it on the cell
+func (g *Game) drawEvaluation(cell engine.Move, screen *ebiten.Image) {
+	move, ok := g.analysis.moves[cell]
+	if !ok {
+		return
+	}
+	probability := move.WinProbability
+	if move.Outcome != ai.Draw {
+		probability = float64(move.Outcome+1) / 2
+	}
+	x, y := graphics.GetPositionOfSymbol(cell)
+	options := &ebiten.DrawImageOptions{}
+	options.GeoM.Translate(x, y)
+	options.ColorScale.ScaleWithColor(color.NRGBA{R: uint8(255 * (1 - probability)), G: uint8(255 * probability), A: 100})
+	screen.DrawImage(gameGraphics.Highlight, options)
+
+	label := evaluationLabel(move)
+	size := gameGraphics.Highlight.Bounds().Dx()
+	bound, _ := font.BoundString(normalText, label)
+	labelX := int(x) + size/2 - (bound.Max.X-bound.Min.X).Ceil()/2
+	text.Draw(screen, label, normalText, labelX, int(y)+size/2+FontSize/3, color.White)
+}
+
 func (g *Game) drawMiniBoard(i, j int, screen *ebiten.Image) {
 
 	for k := 0; k < 3; k++ {
 		for l := 0; l < 3; l++ {
-			symbolInCell := g.gameBoard[i][j].Board[k][l]
-			if symbolInCell == PLAYER1 || symbolInCell == PLAYER2 {
-				g.DrawSymbol(graphics.BoardCoord{MainBoardRow: i, MainBoardCol: j, MiniBoardRow: k, MiniBoardCol: l}, symbolInCell)
+			cell := engine.Move{MainBoardRow: i, MainBoardCol: j, MiniBoardRow: k, MiniBoardCol: l}
+			symbolInCell := g.Cell(cell)
+			if symbolInCell == engine.PLAYER1 || symbolInCell == engine.PLAYER2 {
+				g.DrawSymbol(cell, symbolInCell)
+			} else {
+				g.drawEvaluation(cell, screen)
 			}
 		}
 	}
 
 	gameBoardImageOptions := &ebiten.DrawImageOptions{}
-	gameBoardImageOptions.GeoM.Translate(float64(WindowWidth/3*i), float64(WindowWidth/3*j))
-	if g.isValidPlay(i, j) {
+	gameBoardImageOptions.GeoM.Translate(float64(WindowWidth/3*j), float64(WindowWidth/3*i))
+	if g.IsValidPlay(i, j) {
 		gameBoardImageOptions.ColorScale.Scale(0, 1, 0, 1)
 	}
 
@@ -70,8 +130,14 @@ func (g *Game) drawMiniBoard(i, j int, screen *ebiten.Image) {
 func (g *Game) displayInformation(screen *ebiten.Image) {
 	g.displayFPS(screen)
 	g.displayAIInfo(screen)
+	g.displaySearchPanel(screen)
 	g.displayKeyChangeColor(screen)
 	g.displayScore(screen)
+	g.displayLastMove(screen)
+	g.displayHint(screen)
+	g.displayAnalysis(screen)
+	g.displayStatus(screen)
+	g.displayReplayInformation(screen)
 	g.displayWinner(screen)
 	g.displayGameStartMessage(screen)
 	g.displayCurrentPlayerSymbol(screen)
@@ -84,11 +150,17 @@ func (g *Game) displayFPS(screen *ebiten.Image) {
 
 func (g *Game) displayAIInfo(screen *ebiten.Image) {
 	if g.AIEnabled {
-		msgAI := fmt.Sprintf("AI simulations: %v \nAI win confidence: %0.2f\nAI difficulty: %v ", g.AISimulations, g.AIWinProbability*100, g.AIDifficulty)
+		msgAI := fmt.Sprintf("AI simulations: %v \nAI win confidence: %0.2f\nAI difficulty: %v ", g.AIStats.Simulations, g.AIStats.WinProbability*100, g.AIProfile.Name)
 		text.Draw(screen, msgAI, normalText, 100, WindowHeight-50, color.White)
 	}
 }
 
+func (g *Game) displaySearchPanel(screen *ebiten.Image) {
+	if g.AIEnabled || g.spectator.enabled {
+		text.Draw(screen, searchReport(g.AIStats), normalText, WindowWidth+10, 20, color.White)
+	}
+}
+
 func (g *Game) displayKeyChangeColor(screen *ebiten.Image) {
 	keyChangeColor(ebiten.KeyEscape, screen)
 	keyChangeColor(ebiten.KeyR, screen)
@@ -99,13 +171,44 @@ func (g *Game) displayScore(screen *ebiten.Image) {
 	text.Draw(screen, msgOX, normalText, WindowWidth/2, WindowHeight-5, color.White)
 }
 
+func (g *Game) displayLastMove(screen *ebiten.Image) {
+	if g.Round() > 0 {
+		msgMove := fmt.Sprintf("Last move: %v", g.LastMove())
+		text.Draw(screen, msgMove, normalText, WindowWidth-150, WindowHeight-5, color.White)
+	}
+}
+
+func (g *Game) displayHint(screen *ebiten.Image) {
+	if g.state == Playing && !g.spectator.enabled && g.hint.budget != 0 {
+		text.Draw(screen, g.hintInformation(), normalText, WindowWidth-150, WindowHeight-25, color.White)
+	}
+}
+
+func (g *Game) displayAnalysis(screen *ebiten.Image) {
+	if g.analysis.enabled {
+		text.Draw(screen, g.analysisInformation(), normalText, 10, WindowWidth+40, color.White)
+	}
+}
+
+func (g *Game) displayStatus(screen *ebiten.Image) {
+	text.Draw(screen, g.status, normalText, WindowWidth/2, WindowHeight-50, color.White)
+}
+
+func (g *Game) displayReplayInformation(screen *ebiten.Image) {
+	if g.state == Replaying {
+		text.Draw(screen, g.replayInformation(), normalText, 10, WindowWidth+20, color.White)
+	} else if g.spectator.enabled && g.state != WaitingForGameStart {
+		text.Draw(screen, g.spectatorInformation(), normalText, 10, WindowWidth+20, color.White)
+	}
+}
+
 func (g *Game) displayWinner(screen *ebiten.Image) {
-	if g.win != EMPTY {
+	if g.Winner() != engine.EMPTY {
 		var msgWin = ""
-		if g.win == NONE {
+		if g.Winner() == engine.NONE {
 			msgWin = "Draw!"
 		} else {
-			msgWin = fmt.Sprintf("%v wins!", string(g.win))
+			msgWin = fmt.Sprintf("%v wins!", string(g.Winner()))
 		}
 		text.Draw(screen, msgWin, bigText, 70, 200, color.RGBA{G: 50, B: 200, A: 255})
 	}
@@ -114,10 +217,33 @@ func (g *Game) displayWinner(screen *ebiten.Image) {
 func (g *Game) displayGameStartMessage(screen *ebiten.Image) {
 	if g.state == WaitingForGameStart {
 		msg := ""
-		if g.AIEnabled {
-			msg = "Press SPACE to start\nPress A to switch to multiplayer\nPress 1 to 5 to change AI difficulty"
+		if g.spectator.enabled {
+			msg = fmt.Sprintf("Press SPACE to start the AI versus AI match\nPress M to play yourself\n"+
+				"O: %v\nX: %v\nPress TAB to select a side, now %c\nPress E to change its engine, %s its difficulty",
+				g.spectator.settings[engine.PLAYER1], g.spectator.settings[engine.PLAYER2], g.spectator.selected,
+				g.profileKeys())
+		} else if g.AIEnabled {
+			msg = fmt.Sprintf("Press SPACE to start\nPress A to switch to multiplayer\nPress E to change the AI engine, now %s\n"+
+				"Press %s to change AI difficulty, now %v\n"+
+				"Press S to change the side of the AI, now %c\nPress P to let the AI think during your turn, now %s\n"+
+				"Press Ctrl+Z / Ctrl+Y to undo / redo moves", engineNames[g.AIEngine], g.profileKeys(), g.AIProfile,
+				g.AISide, onOff(g.AIPondering))
 		} else {
-			msg = "Press SPACE to start\nPress A to enable AI"
+			msg = "Press SPACE to start\nPress A to enable AI\nPress Ctrl+Z / Ctrl+Y to undo / redo moves"
+		}
+		if !g.spectator.enabled && g.hint.budget != 0 {
+			msg += "\nPress H during your turn for a hint"
+		}
+		if !g.spectator.enabled {
+			msg += "\nPress M to watch AI versus AI"
+		}
+		msg += "\nPress I during the game to show the analysis of the moves"
+		msg += "\nPress Ctrl+S / Ctrl+L to save / load the game"
+		if g.canResume {
+			msg += "\nPress C to continue the last game"
+		}
+		if g.canReplay {
+			msg += "\nPress V to replay the last finished game"
 		}
 		widthX, _ := font.BoundString(normalText, msg)
 		text.Draw(screen, msg, normalText, int(WindowWidth/2-widthX.Min.X), WindowHeight/2, color.RGBA{0, 255, 255, 255})
@@ -126,7 +252,7 @@ func (g *Game) displayGameStartMessage(screen *ebiten.Image) {
 
 func (g *Game) displayCurrentPlayerSymbol(screen *ebiten.Image) {
 	mx, my := ebiten.CursorPosition()
-	currentPlayerSymbol := string(g.playing)
+	currentPlayerSymbol := string(g.Playing())
 	text.Draw(screen, currentPlayerSymbol, normalText, mx, my, color.RGBA{R: 239, G: 215, A: 128})
 }
 
@@ -197,3 +323,10 @@ func getCenteredTextPosition(text string) (int, int) {
 	y := WindowHeight / 2
 	return int(x), y
 }
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
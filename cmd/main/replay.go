@@ -0,0 +1,141 @@
+package main
+
+import (
+	"GoTicTacToe/lib/engine"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+const (
+	lastGameFileName   = "last-game.txt"
+	defaultReplaySpeed = 1   // moves per second
+	minReplaySpeed     = 0.5 // moves per second
+	maxReplaySpeed     = 8   // moves per second
+)
+
+// replay holds the settings of the replay viewer
+type replay struct {
+	length   int     // number of moves of the replayed game
+	autoplay bool    // true when the moves are played automatically
+	speed    float64 // moves per second played automatically
+	ticks    int     // ticks since the last automatic move
+}
+
+// newRecord returns the record of the current game with the players of the session
+func (g *Game) newRecord() *engine.Record {
+	record := engine.NewRecord(g.Game)
+	record.PlayerO = g.players[engine.PLAYER1].Name()
+	record.PlayerX = g.players[engine.PLAYER2].Name()
+	if g.AIEnabled && !g.spectator.enabled {
+		record.AIDifficulty = g.AIProfile.Name
+	}
+	return record
+}
+
+// saveLastGame writes the record of the current game, so it can be replayed from the start screen
+func (g *Game) saveLastGame() {
+	path, err := sessionPath(lastGameFileName)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(path, []byte(g.newRecord().String()), 0o644)
+	}
+	if err != nil {
+		log.Println("saving the game record failed:", err)
+	}
+}
+
+// hasLastGame tells if a finished game can be replayed
+func hasLastGame() bool {
+	path, err := sessionPath(lastGameFileName)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// loadReplay reads a game record and starts replaying it
+func (g *Game) loadReplay(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	record, err := engine.ParseRecord(string(data))
+	if err != nil {
+		return err
+	}
+	return g.startReplay(record)
+}
+
+// startReplay shows the recorded game from its first move
+func (g *Game) startReplay(record *engine.Record) error {
+	game, err := record.Game()
+	if err != nil {
+		return err
+	}
+	for game.Undo() {
+	}
+	g.Game = game
+	g.replay = replay{length: len(record.Moves), speed: defaultReplaySpeed}
+	g.state = Replaying
+	return nil
+}
+
+// updateReplay steps through the replayed game:
+// arrows left and right go one move back or forward, Home and End jump to the start or the end,
+// space toggles the autoplay whose speed is changed with the arrows up and down, Q leaves the replay
+func (g *Game) updateReplay() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+		g.Redo()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+		g.Undo()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
+		for g.Undo() {
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnd) {
+		for g.Redo() {
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		g.replay.autoplay = !g.replay.autoplay
+		g.replay.ticks = 0
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
+		g.replay.speed = min(g.replay.speed*2, maxReplaySpeed)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
+		g.replay.speed = max(g.replay.speed/2, minReplaySpeed)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+		g.Load()
+		return
+	}
+
+	if g.replay.autoplay {
+		g.replay.ticks++
+		if float64(g.replay.ticks) >= float64(ebiten.TPS())/g.replay.speed {
+			g.replay.ticks = 0
+			g.replay.autoplay = g.Redo() && g.CanRedo()
+		}
+	}
+}
+
+// replayInformation describes the position of the replay and its controls
+func (g *Game) replayInformation() string {
+	autoplay := "paused"
+	if g.replay.autoplay {
+		autoplay = fmt.Sprintf("playing at %v moves/s", g.replay.speed)
+	}
+	return fmt.Sprintf("Replay: move %d/%d, %s\n"+
+		"LEFT / RIGHT: step, HOME / END: jump, SPACE: autoplay, UP / DOWN: speed, Q: quit",
+		g.Round(), g.replay.length, autoplay)
+}
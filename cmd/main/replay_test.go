@@ -0,0 +1,57 @@
+package main
+
+import (
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"testing"
+)
+
+func TestReplayLastGame(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.state = Playing
+	for game.state == Playing {
+		if err := game.makePlay(game.PossibleMoves()[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	final := game.Game.String()
+	length := game.Round()
+
+	if !hasLastGame() || !game.canReplay {
+		t.Fatalf("Expected the finished game to be recorded")
+	}
+	path, err := sessionPath(lastGameFileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := game.loadReplay(path); err != nil {
+		t.Fatal(err)
+	}
+	if game.state != Replaying || game.Round() != 0 || game.replay.length != length {
+		t.Fatalf("Expected the replay to start at the first move of %d", length)
+	}
+	for game.Redo() {
+	}
+	if game.Game.String() != final {
+		t.Errorf("Expected final position %q, got %q", final, game.Game)
+	}
+}
+
+func TestNewRecordPlayers(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.AIProfile, _ = player.FindProfile(game.profiles, "easy")
+	game.AISide = engine.PLAYER1
+	game.setupPlayers()
+
+	record := game.newRecord()
+	if record.PlayerO != "MCTS 4000 simulations temperature 0.25 15% blind" || record.PlayerX != "Human" || record.AIDifficulty != "easy" {
+		t.Errorf("Unexpected record headers: %+v", record)
+	}
+	if record.Result != engine.EMPTY {
+		t.Errorf("Unexpected result: %q", record.Result)
+	}
+}
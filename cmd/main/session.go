@@ -0,0 +1,223 @@
+package main
+
+import (
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	sessionVersion   = 1
+	sessionDirName   = "ultimate-tic-tac-toe"
+	saveFileName     = "save.json"
+	autosaveFileName = "autosave.json"
+)
+
+// session is the content of a save file: the game in progress, the score and the AI settings
+type session struct {
+	Version      int      `json:"version"`
+	First        string   `json:"first"`    // player of the first move
+	Moves        []string `json:"moves"`    // moves played, in move notation
+	Position     string   `json:"position"` // position after the moves, checked when loading
+	PointsO      int      `json:"pointsO"`
+	PointsX      int      `json:"pointsX"`
+	AIEnabled    bool     `json:"aiEnabled"`
+	AIDifficulty float64  `json:"aiDifficulty,omitempty"` // seconds of search of the saves without profile
+	AIProfile    string   `json:"aiProfile,omitempty"`    // difficulty profile of the AI, medium when missing
+	AISide       string   `json:"aiSide,omitempty"`       // side played by the AI, X when missing
+	AIPondering  bool     `json:"aiPondering,omitempty"`
+	AIEngine     string   `json:"aiEngine,omitempty"` // engine of the AI, MCTS when missing
+}
+
+// sessionPath returns the path of a save file in the user configuration directory
+func sessionPath(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, sessionDirName, name), nil
+}
+
+// newSession captures the current session
+func (g *Game) newSession() session {
+	record := engine.NewRecord(g.Game)
+	moves := make([]string, len(record.Moves))
+	for i, move := range record.Moves {
+		moves[i] = move.String()
+	}
+	return session{
+		Version:     sessionVersion,
+		First:       string(record.First),
+		Moves:       moves,
+		Position:    g.Game.String(),
+		PointsO:     g.pointsO,
+		PointsX:     g.pointsX,
+		AIEnabled:   g.AIEnabled,
+		AIProfile:   g.AIProfile.Name,
+		AISide:      string(g.AISide),
+		AIPondering: g.AIPondering,
+		AIEngine:    engineNames[g.AIEngine],
+	}
+}
+
+// restoreSession replaces the current session, the game is left unchanged when the session is invalid
+func (g *Game) restoreSession(s session) error {
+	if s.Version != sessionVersion {
+		return fmt.Errorf("unsupported save version %d", s.Version)
+	}
+	if s.First != string(engine.PLAYER1) && s.First != string(engine.PLAYER2) {
+		return fmt.Errorf("unexpected first player %q", s.First)
+	}
+	game := engine.NewGame(engine.GameSymbol(s.First[0]))
+	for _, notation := range s.Moves {
+		move, err := engine.ParseMove(notation)
+		if err != nil {
+			return err
+		}
+		if err := game.Play(move); err != nil {
+			return err
+		}
+	}
+	if game.String() != s.Position {
+		return fmt.Errorf("moves give position %q instead of %q", game.String(), s.Position)
+	}
+
+	g.Game = game
+	g.resetHints()
+	g.pointsO = s.PointsO
+	g.pointsX = s.PointsX
+	g.AIEnabled = s.AIEnabled
+	g.restoreProfile(s)
+	g.AIPondering = s.AIPondering
+	g.AIEngine = engineMCTS
+	for index, name := range engineNames {
+		if s.AIEngine == name {
+			g.AIEngine = index
+		}
+	}
+	g.AISide = engine.PLAYER2
+	if s.AISide == string(engine.PLAYER1) {
+		g.AISide = engine.PLAYER1
+	}
+	g.setupPlayers()
+	if g.IsOver() {
+		g.state = PlayAgain
+	} else {
+		g.state = Playing
+	}
+	return nil
+}
+
+// restoreProfile sets the difficulty profile of the session, and the default profile when the profile is unknown.
+// The difficulty N of the older saves was a search of N seconds, it becomes the profile with the closest duration.
+func (g *Game) restoreProfile(s session) {
+	if profile, ok := player.FindProfile(g.profiles, s.AIProfile); ok {
+		g.AIProfile = profile
+		return
+	}
+	if s.AIProfile == "" && s.AIDifficulty > 0 {
+		budget := time.Duration(s.AIDifficulty * float64(time.Second))
+		found := false
+		for _, profile := range g.profiles {
+			duration := profile.Config.Duration
+			if duration <= 0 || profile.Config.Iterations > 0 {
+				continue
+			}
+			if !found || (duration-budget).Abs() < (g.AIProfile.Config.Duration-budget).Abs() {
+				g.AIProfile, found = profile, true
+			}
+		}
+		if found {
+			return
+		}
+	}
+	g.AIProfile, _ = player.FindProfile(g.profiles, defaultProfileName)
+}
+
+// writeSession saves the session to the file, creating its directory if needed
+func writeSession(path string, s session) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// readSession loads a session saved by writeSession
+func readSession(path string) (session, error) {
+	var s session
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+// save writes the current session to the named save file
+func (g *Game) save(name string) error {
+	path, err := sessionPath(name)
+	if err != nil {
+		return err
+	}
+	return writeSession(path, g.newSession())
+}
+
+// load restores the session of the named save file
+func (g *Game) load(name string) error {
+	path, err := sessionPath(name)
+	if err != nil {
+		return err
+	}
+	s, err := readSession(path)
+	if err != nil {
+		return err
+	}
+	return g.restoreSession(s)
+}
+
+// canSave tells if the session can be saved: not while the AI is thinking,
+// and never for replays or AI versus AI matches that are not games of the player
+func (g *Game) canSave() bool {
+	return !g.AIRunning && g.state != Replaying && !g.spectator.enabled
+}
+
+// autosave saves the session when leaving, unless there is nothing worth resuming
+func (g *Game) autosave() {
+	if !g.canSave() || (g.Round() == 0 && g.pointsO == 0 && g.pointsX == 0) {
+		return
+	}
+	if err := g.save(autosaveFileName); err != nil {
+		log.Println("autosave failed:", err)
+	}
+}
+
+// hasAutosave tells if a session saved when leaving can be resumed
+func hasAutosave() bool {
+	path, err := sessionPath(autosaveFileName)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// resume restores the session saved when leaving and removes it so it is offered only once
+func (g *Game) resume() error {
+	if err := g.load(autosaveFileName); err != nil {
+		return err
+	}
+	path, err := sessionPath(autosaveFileName)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.state = Playing
+	for i := 0; i < 10; i++ {
+		if err := game.makePlay(game.PossibleMoves()[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	game.pointsO = 3
+	game.pointsX = 1
+	game.AIProfile, _ = player.FindProfile(game.profiles, "hard")
+	game.AIPondering = true
+	game.AIEngine = engineMinimax
+
+	path := filepath.Join(t.TempDir(), saveFileName)
+	if err := writeSession(path, game.newSession()); err != nil {
+		t.Fatal(err)
+	}
+	s, err := readSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := &Game{}
+	restored.init()
+	if err := restored.restoreSession(s); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Game.String() != game.Game.String() || restored.Round() != game.Round() {
+		t.Errorf("Expected position %q, got %q", game.Game, restored.Game)
+	}
+	if restored.pointsO != 3 || restored.pointsX != 1 || restored.AIProfile.Name != "hard" || !restored.AIPondering || restored.AIEngine != engineMinimax {
+		t.Errorf("Unexpected session: %d %d %v", restored.pointsO, restored.pointsX, restored.AIProfile.Name)
+	}
+	if restored.state != Playing || !restored.CanUndo() {
+		t.Errorf("Expected a game in progress with its history")
+	}
+}
+
+func TestRestoreSessionDifficulty(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	s := game.newSession()
+	for difficulty, expected := range map[float64]string{0.5: "medium", 1: "medium", 2: "hard", 3: "hard", 4: "expert", 5: "expert"} {
+		s.AIProfile, s.AIDifficulty = "", difficulty
+		if err := game.restoreSession(s); err != nil {
+			t.Fatal(err)
+		}
+		if game.AIProfile.Name != expected {
+			t.Errorf("Expected the difficulty %g of an older save to be %s, got %s", difficulty, expected, game.AIProfile.Name)
+		}
+	}
+	s.AIProfile = "unknown"
+	if err := game.restoreSession(s); err != nil {
+		t.Fatal(err)
+	}
+	if game.AIProfile.Name != defaultProfileName {
+		t.Errorf("Expected the default profile for an unknown profile, got %s", game.AIProfile.Name)
+	}
+}
+
+func TestRestoreOldSession(t *testing.T) {
+	useTempConfigDir(t)
+	// a save written before the difficulty profiles, with the default difficulty of 2 seconds
+	old := engine.NewGame(engine.PLAYER1)
+	move, _ := engine.ParseMove("e5")
+	if err := old.Play(move); err != nil {
+		t.Fatal(err)
+	}
+	data := fmt.Sprintf(`{"version": 1, "first": %q, "moves": ["e5"], "position": %q, "pointsO": 2, "pointsX": 1,
+		"aiEnabled": true, "aiDifficulty": 2}`, string(engine.PLAYER1), old.String())
+	path := filepath.Join(t.TempDir(), saveFileName)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := readSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := &Game{}
+	game.init()
+	if err := game.restoreSession(s); err != nil {
+		t.Fatal(err)
+	}
+	if game.AIProfile.Name != "hard" || game.Round() != 1 || game.pointsO != 2 {
+		t.Errorf("Expected the game of the old save with the hard profile, got %s at round %d", game.AIProfile.Name, game.Round())
+	}
+}
+
+func TestRestoreInvalidSession(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	s := game.newSession()
+	s.Moves = []string{"e5", "e5"}
+
+	if err := game.restoreSession(s); err == nil {
+		t.Errorf("Expected an error for an illegal move")
+	}
+	if game.Round() != 0 || game.Playing() != engine.GameSymbol(s.First[0]) {
+		t.Errorf("Invalid session changed the game")
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{profilesPath: filepath.Join(t.TempDir(), profilesFileName)}
+	data := `[{"name": "blitz", "duration": "100ms"}]`
+	if err := os.WriteFile(game.profilesPath, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	game.init()
+	if last := game.profiles[len(game.profiles)-1]; last.Name != "blitz" || game.AIProfile.Name != defaultProfileName {
+		t.Errorf("Expected the profile of the file after the default ones, got %s", last.Name)
+	}
+
+	game = &Game{profilesPath: filepath.Join(t.TempDir(), "missing.json")}
+	game.init()
+	if len(game.profiles) != len(player.DefaultProfiles()) || game.status == "" {
+		t.Errorf("Expected the default profiles and an error for a missing file")
+	}
+}
+
+// useTempConfigDir makes the save files of the test go to a temporary directory
+func useTempConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"time"
+)
+
+const (
+	defaultMoveDelay = 500 * time.Millisecond // pause between two moves of the AI versus AI mode
+	maxMoveDelay     = 5 * time.Second
+	moveDelayStep    = 250 * time.Millisecond
+	nextGameDelay    = 3 * time.Second // pause before the next game of the AI versus AI mode
+)
+
+// engines selectable for the AI, and for each side of the AI versus AI mode
+const (
+	engineMCTS = iota
+	engineMinimax
+	engineRandom
+	engineCount
+)
+
+var engineNames = [engineCount]string{"MCTS", "Minimax", "Random"}
+
+// aiSettings is the engine chosen for the AI, or for one side of the AI versus AI mode
+type aiSettings struct {
+	engine  int            // one of the engine constants
+	profile player.Profile // difficulty level
+}
+
+// spectator holds the settings of the AI versus AI mode, where the human watches two engines play
+type spectator struct {
+	enabled   bool                              // true when both sides are played by engines
+	settings  map[engine.GameSymbol]*aiSettings // engine of each side
+	selected  engine.GameSymbol                 // side whose engine is changed on the start screen
+	moveDelay time.Duration                     // pause between two moves
+	paused    bool                              // true when no move is started
+	waitTicks int                               // ticks since the last move or the end of the game
+}
+
+func newSpectator(profile player.Profile) spectator {
+	return spectator{
+		settings: map[engine.GameSymbol]*aiSettings{
+			engine.PLAYER1: {engine: engineMCTS, profile: profile},
+			engine.PLAYER2: {engine: engineMCTS, profile: profile},
+		},
+		selected:  engine.PLAYER1,
+		moveDelay: defaultMoveDelay,
+	}
+}
+
+// weakMinimaxDepth is the depth searched by the minimax engine with the profiles without thinking time,
+// which are the weak ones
+const weakMinimaxDepth = 2
+
+// newPlayer creates the engine of the settings, the minimax engine only uses the thinking time of the profile
+func (s *aiSettings) newPlayer() player.Player {
+	switch s.engine {
+	case engineMinimax:
+		config := ai.MinimaxConfig{Duration: s.profile.Config.Duration}
+		if config.Duration == 0 {
+			config.Depth = weakMinimaxDepth
+		}
+		return player.NewMinimax(config)
+	case engineRandom:
+		return player.NewRandom(time.Now().UnixNano())
+	}
+	return player.NewProfileMCTS(s.profile, time.Now().UnixNano())
+}
+
+func (s *aiSettings) String() string {
+	if s.engine == engineRandom {
+		return engineNames[s.engine]
+	}
+	return fmt.Sprintf("%s %s", engineNames[s.engine], s.profile.Name)
+}
+
+// updateSpectatorSettings changes the engines on the start screen:
+// TAB selects a side, E changes its engine and 1 to 9 its difficulty
+func (g *Game) updateSpectatorSettings() {
+	selected := g.spectator.settings[g.spectator.selected]
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		g.spectator.selected = g.spectator.selected.Opponent()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+		selected.engine = (selected.engine + 1) % engineCount
+	}
+	if profile, ok := g.pressedProfile(); ok {
+		selected.profile = profile
+	}
+}
+
+// updateSpectator handles the keys of a running AI versus AI match:
+// P pauses or resumes the match, the arrows up and down change the pause between two moves.
+// The next game is started automatically at the end of a game.
+func (g *Game) updateSpectator() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) && !isControlPressed() {
+		g.spectator.paused = !g.spectator.paused
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
+		g.spectator.moveDelay = min(g.spectator.moveDelay+moveDelayStep, maxMoveDelay)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
+		g.spectator.moveDelay = max(g.spectator.moveDelay-moveDelayStep, 0)
+	}
+
+	if g.state == PlayAgain && g.waited(nextGameDelay) {
+		g.Load()
+		g.setupPlayers()
+		g.state = Playing
+	}
+}
+
+// waited counts the ticks of a pause of the match and tells when it is over, it never ends while paused
+func (g *Game) waited(delay time.Duration) bool {
+	if g.spectator.paused {
+		return false
+	}
+	g.spectator.waitTicks++
+	if float64(g.spectator.waitTicks) < delay.Seconds()*float64(ebiten.TPS()) {
+		return false
+	}
+	g.spectator.waitTicks = 0
+	return true
+}
+
+// spectatorInformation describes the match and its controls
+func (g *Game) spectatorInformation() string {
+	state := fmt.Sprintf("delay %v (UP / DOWN), P: pause", g.spectator.moveDelay)
+	if g.spectator.paused {
+		state = "paused, P: resume"
+	}
+	return fmt.Sprintf("O: %v vs X: %v, %s",
+		g.spectator.settings[engine.PLAYER1], g.spectator.settings[engine.PLAYER2], state)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"GoTicTacToe/lib/engine"
+	"GoTicTacToe/lib/player"
+	"testing"
+)
+
+func TestSpectatorPlayers(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.spectator.enabled = true
+	game.spectator.settings[engine.PLAYER1].profile, _ = player.FindProfile(game.profiles, "medium")
+	game.spectator.settings[engine.PLAYER2].engine = engineRandom
+	game.setupPlayers()
+
+	if game.isHumanTurn() {
+		t.Errorf("Expected no human player in the AI versus AI mode")
+	}
+	if name := game.players[engine.PLAYER1].Name(); name != "MCTS 1s" {
+		t.Errorf("Expected O to be played by the medium MCTS, got %s", name)
+	}
+	if settings := game.spectator.settings[engine.PLAYER1].String(); settings != "MCTS medium" {
+		t.Errorf("Expected the profile in the settings of O, got %s", settings)
+	}
+	if name := game.players[engine.PLAYER2].Name(); name != "Random" {
+		t.Errorf("Expected X to be played by Random, got %s", name)
+	}
+	if record := game.newRecord(); record.AIDifficulty != "" {
+		t.Errorf("Expected no AI difficulty in the record of a match, got %q", record.AIDifficulty)
+	}
+	if game.canSave() {
+		t.Errorf("Expected a match not to be saved")
+	}
+}
+
+func TestSpectatorWaitedWhilePaused(t *testing.T) {
+	useTempConfigDir(t)
+	game := &Game{}
+	game.init()
+	game.spectator.enabled = true
+	if !game.waited(0) {
+		t.Errorf("Expected no pause without delay")
+	}
+	game.spectator.paused = true
+	if game.waited(0) {
+		t.Errorf("Expected no move while paused")
+	}
+}
@@ -0,0 +1,109 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config sets the budget and the randomness of a search.
+// The search stops at the first budget reached, a budget of 0 being unlimited, and always runs at least one simulation.
+// With an iteration budget only, the same configuration always plays the same move in the same position.
+type Config struct {
+	Iterations int           // number of simulations per move, shared between the workers
+	Duration   time.Duration // thinking time per move
+	Seed       int64         // seed of the random moves of the search
+	Workers    int           // number of goroutines searching in parallel, 1 when 0
+	// SolverCells is the number of open cells below which the position is solved exactly before searching,
+	// the solver is not used when 0
+	SolverCells int
+	Policy      PlayoutPolicy // policy of the moves of the simulations, UniformPolicy when nil
+	// RAVE is the equivalence parameter of the rapid action value estimation, blending the win rates of the moves
+	// with their all-moves-as-first win rates: the larger, the longer these are trusted. RAVE is not used when 0.
+	RAVE float64
+	// Exploration is the exploration constant of the UCT formula, DefaultExploration when not set:
+	// the larger, the more the search spreads its simulations over the moves instead of deepening the best ones
+	Exploration Parameter
+	// DrawReward is the result of a drawn simulation between a loss (0) and a win (1),
+	// DefaultDrawReward when not set: the larger, the more the search is content with a draw
+	DrawReward Parameter
+	// MistakeRate is the probability to play a random legal move instead of the move found by the search,
+	// to make the player weaker
+	MistakeRate float64
+	// Temperature makes the move drawn among the explored moves with a probability proportional to
+	// their visits^(1/Temperature) instead of being the most visited one, the move is not drawn when 0.
+	// At 1, the moves are played as often as the search visited them, the lower the more often the best one is.
+	Temperature float64
+	// ThreatBlindness is the probability to overlook the threats of the opponent on a move:
+	// the moves blocking a mini-board or the game of the opponent are then not played, unless they win a mini-board
+	ThreatBlindness float64
+}
+
+// Parameter is a setting of Config that keeps its default until it is set, even to 0
+type Parameter struct {
+	Value float64
+	Set   bool
+}
+
+// SetTo returns the parameter set to the value
+func SetTo(value float64) Parameter {
+	return Parameter{Value: value, Set: true}
+}
+
+// or returns the value of the parameter, the default when it is not set
+func (p Parameter) or(defaultValue float64) float64 {
+	if !p.Set {
+		return defaultValue
+	}
+	return p.Value
+}
+
+func (c Config) exploration() float64 {
+	return c.Exploration.or(DefaultExploration)
+}
+
+func (c Config) drawReward() float64 {
+	return c.DrawReward.or(DefaultDrawReward)
+}
+
+// done tells if the budget is spent after the given number of simulations started at start
+func (c Config) done(simulations int, start time.Time) bool {
+	if simulations == 0 {
+		return false
+	}
+	if c.Iterations <= 0 && c.Duration <= 0 {
+		return true
+	}
+	return (c.Iterations > 0 && simulations >= c.Iterations) || (c.Duration > 0 && time.Since(start) >= c.Duration)
+}
+
+// String describes the budget, the playout policy when it is not uniform, RAVE when it is used
+// and the weaknesses, like "3s" or "10000 simulations tactical rave 10% mistakes"
+func (c Config) String() string {
+	var budget []string
+	if c.Iterations > 0 {
+		budget = append(budget, fmt.Sprintf("%d simulations", c.Iterations))
+	}
+	if c.Duration > 0 {
+		budget = append(budget, c.Duration.String())
+	}
+	if len(budget) == 0 {
+		budget = append(budget, "1 simulation")
+	}
+	if c.Policy != nil && c.Policy != (UniformPolicy{}) {
+		budget = append(budget, c.Policy.Name())
+	}
+	if c.RAVE > 0 {
+		budget = append(budget, "rave")
+	}
+	if c.MistakeRate > 0 {
+		budget = append(budget, fmt.Sprintf("%.3g%% mistakes", c.MistakeRate*100))
+	}
+	if c.Temperature > 0 {
+		budget = append(budget, fmt.Sprintf("temperature %g", c.Temperature))
+	}
+	if c.ThreatBlindness > 0 {
+		budget = append(budget, fmt.Sprintf("%.3g%% blind", c.ThreatBlindness*100))
+	}
+	return strings.Join(budget, " ")
+}
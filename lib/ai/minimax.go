@@ -0,0 +1,179 @@
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"fmt"
+	"math/bits"
+	"time"
+)
+
+// MinimaxConfig sets the budget of the minimax engine, the deepening stops at the first budget reached.
+// The search always completes the depth of one move.
+type MinimaxConfig struct {
+	Depth    int           // maximal depth in moves, unlimited when 0
+	Duration time.Duration // thinking time per move, unlimited when 0
+}
+
+// String describes the budget, like "3s", "depth 6" or "unlimited"
+func (c MinimaxConfig) String() string {
+	switch {
+	case c.Depth > 0 && c.Duration > 0:
+		return fmt.Sprintf("depth %d %v", c.Depth, c.Duration)
+	case c.Depth > 0:
+		return fmt.Sprintf("depth %d", c.Depth)
+	case c.Duration > 0:
+		return c.Duration.String()
+	}
+	return "unlimited"
+}
+
+// MinimaxResult is the result of a minimax search
+type MinimaxResult struct {
+	Move  engine.Move // best move found
+	Score int         // evaluation of the move for the player to move
+	Depth int         // depth of the last completed iteration
+	Nodes int         // number of positions evaluated or expanded
+}
+
+// weights of the evaluation
+const (
+	winScore        = 1000000 // game won, reduced by the number of moves needed to win
+	boardScore      = 100     // mini-board won
+	centerBoard     = 30      // bonus of the center mini-board won
+	macroThreat     = 250     // two mini-boards won on a line which is still open
+	miniThreat      = 12      // two cells of a mini-board on a line which is still open
+	centerCell      = 3       // center cell of an open mini-board
+	freeChoiceScore = 40      // the player to move can play in any open mini-board
+)
+
+// minimax is a depth-limited negamax search with alpha-beta pruning, stopped by the context
+type minimax struct {
+	ctx     context.Context
+	nodes   int
+	stopped bool
+}
+
+// MinimaxSearch finds a move with an alpha-beta search of increasing depth using a handwritten evaluation.
+// When the budget or the context stops an iteration, the move of the last completed iteration is returned.
+// The move is engine.NoMove when the game is over.
+func MinimaxSearch(ctx context.Context, g *engine.Game, config MinimaxConfig) MinimaxResult {
+	root := engine.NewBitBoard(g)
+	if root.IsOver() {
+		return MinimaxResult{Move: engine.NoMove}
+	}
+	if config.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Duration)
+		defer cancel()
+	}
+	// the first depth is always completed
+	m := &minimax{ctx: context.Background()}
+	moves := root.Moves(nil)
+	result := MinimaxResult{Move: engine.NoMove}
+	for depth := 1; config.Depth <= 0 || depth <= config.Depth; depth++ {
+		best, score := m.searchRoot(&root, moves, depth)
+		if m.stopped {
+			break
+		}
+		result = MinimaxResult{Move: best.Move(), Score: score, Depth: depth, Nodes: m.nodes}
+		if score >= winScore-depth || score <= -winScore+depth || depth >= root.OpenCells() {
+			// the outcome is known, searching deeper does not change it
+			break
+		}
+		m.ctx = ctx
+		// the best move is searched first at the next depth
+		for i, move := range moves {
+			if move == best {
+				copy(moves[1:i+1], moves[:i])
+				moves[0] = best
+				break
+			}
+		}
+	}
+	result.Nodes = m.nodes
+	return result
+}
+
+// searchRoot returns the best of the moves of the position at the depth with its score
+func (m *minimax) searchRoot(b *engine.BitBoard, moves []engine.BitMove, depth int) (engine.BitMove, int) {
+	alpha, beta := -winScore-1, winScore+1
+	best := moves[0]
+	for _, move := range moves {
+		child := *b
+		child.Play(move)
+		score := -m.negamax(&child, depth-1, 1, -beta, -alpha)
+		if score > alpha {
+			alpha, best = score, move
+		}
+	}
+	return best, alpha
+}
+
+// negamax returns the score of the position for the player to move, ply moves after the root
+func (m *minimax) negamax(b *engine.BitBoard, depth, ply int, alpha, beta int) int {
+	m.nodes++
+	if m.nodes%checkInterval == 0 && m.ctx.Err() != nil {
+		m.stopped = true
+	}
+	if m.stopped {
+		return 0
+	}
+	if b.IsOver() {
+		if b.Winner() == engine.NONE {
+			return 0
+		}
+		// the winner is the player who just moved, a later loss is better
+		return -winScore + ply
+	}
+	if depth == 0 {
+		return Evaluate(b)
+	}
+
+	var buffer [81]engine.BitMove
+	moves := orderMoves(b, b.Moves(buffer[:0]), 0, false)
+	for _, move := range moves {
+		child := *b
+		child.Play(move)
+		score := -m.negamax(&child, depth-1, ply+1, -beta, -alpha)
+		if score > alpha {
+			alpha = score
+			if alpha >= beta {
+				break
+			}
+		}
+	}
+	return alpha
+}
+
+// Evaluate scores a position which is not over for the player to move, positive when it is ahead:
+// mini-boards won, two mini-boards or two cells on a line that can still be completed,
+// center cells, and the free choice of the mini-board when the opponent was sent to a decided one
+func Evaluate(b *engine.BitBoard) int {
+	player := b.Playing()
+	score := side(b, player) - side(b, player.Opponent())
+	if b.FreeChoice() {
+		score += freeChoiceScore
+	}
+	return score
+}
+
+// side scores the position of one player
+func side(b *engine.BitBoard, player engine.GameSymbol) int {
+	opponent := player.Opponent()
+	won := b.Boards(player)
+	drawn := b.Decided() &^ (won | b.Boards(opponent))
+	score := bits.OnesCount16(won)*boardScore + engine.Threats(won, b.Boards(opponent)|drawn)*macroThreat
+	if won&(1<<4) != 0 {
+		score += centerBoard
+	}
+	for open := ^b.Decided() & (1<<9 - 1); open != 0; open &= open - 1 {
+		board := bits.TrailingZeros16(open)
+		cells := b.Cells(board, player)
+		score += engine.Threats(cells, b.Cells(board, opponent)) * miniThreat
+		if cells&(1<<4) != 0 {
+			score += centerCell
+		}
+	}
+	return score
+}
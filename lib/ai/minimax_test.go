@@ -0,0 +1,109 @@
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestMinimaxAgreesWithSolver(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 20; i++ {
+		game := endgame(rng, 10)
+		if game.IsOver() {
+			continue
+		}
+		solved, err := Solve(context.Background(), engine.NewBitBoard(game))
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := MinimaxSearch(context.Background(), game, MinimaxConfig{Depth: 10})
+		outcome := Draw
+		if result.Score >= winScore-result.Depth {
+			outcome = Win
+		} else if result.Score <= -winScore+result.Depth {
+			outcome = Loss
+		}
+		if outcome != solved[0].Outcome && (outcome != Draw || result.Depth < 10) {
+			t.Errorf("Expected %v at depth %d, got score %d", solved[0].Outcome, result.Depth, result.Score)
+		}
+		if outcome == Win {
+			child := engine.NewBitBoard(game)
+			child.Play(engine.NewBitMove(result.Move))
+			if -bruteForce(child) != Win {
+				t.Errorf("Expected %v to win", result.Move)
+			}
+		}
+	}
+}
+
+func TestMinimaxDeepening(t *testing.T) {
+	game := initGame()
+	result := MinimaxSearch(context.Background(), game, MinimaxConfig{Depth: 3})
+	if result.Depth != 3 || !game.IsValidPlay(result.Move.MainBoardRow, result.Move.MainBoardCol) {
+		t.Errorf("Expected a legal move at depth 3, got %+v", result)
+	}
+	if again := MinimaxSearch(context.Background(), game, MinimaxConfig{Depth: 3}); again != result {
+		t.Errorf("Expected the same result %+v, got %+v", result, again)
+	}
+
+	start := time.Now()
+	result = MinimaxSearch(context.Background(), game, MinimaxConfig{Duration: 100 * time.Millisecond})
+	if elapsed := time.Since(start); elapsed > time.Second || result.Depth < 2 {
+		t.Errorf("Expected a few iterations in 100ms, got depth %d in %v", result.Depth, elapsed)
+	}
+}
+
+func TestMinimaxFinishedGame(t *testing.T) {
+	game := initGame()
+	for !game.IsOver() {
+		if err := game.Play(game.PossibleMoves()[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if result := MinimaxSearch(context.Background(), game, MinimaxConfig{Depth: 3}); result.Move != engine.NoMove {
+		t.Errorf("Expected no move in a finished game, got %+v", result)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	game := initGame()
+	b := engine.NewBitBoard(game)
+	if score := Evaluate(&b); score != freeChoiceScore {
+		t.Errorf("Expected only the free choice in the first position, got %d", score)
+	}
+	for _, notation := range []string{"e5", "e1"} {
+		move, _ := engine.ParseMove(notation)
+		if err := game.Play(move); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// O has the center of the center mini-board and plays in a1
+	b = engine.NewBitBoard(game)
+	if score := Evaluate(&b); score != centerCell {
+		t.Errorf("Expected the center cell for O, got %d", score)
+	}
+}
+
+func BenchmarkMinimaxSearch(b *testing.B) {
+	game := initGame()
+	for i := 0; i < b.N; i++ {
+		MinimaxSearch(context.Background(), game, MinimaxConfig{Depth: 6})
+	}
+}
+
+func TestMinimaxConfigString(t *testing.T) {
+	tests := map[MinimaxConfig]string{
+		{}:                                    "unlimited",
+		{Depth: 6}:                            "depth 6",
+		{Duration: 3 * time.Second}:           "3s",
+		{Depth: 4, Duration: 2 * time.Second}: "depth 4 2s",
+	}
+	for config, expected := range tests {
+		if config.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, config.String())
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Package ai implements the computer player of the game, a Monte Carlo tree search running on engine.BitBoard positions.
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Default parameters of the Monte Carlo Tree Search, used when the configuration leaves them at 0
+const (
+	// DefaultExploration is the exploration constant of the UCT formula,
+	// balance between exploration and exploitation
+	DefaultExploration = math.Sqrt2
+	// DefaultDrawReward is the result of a drawn simulation for both players, between a loss (0) and a win (1)
+	DefaultDrawReward = 0.2
+)
+
+// Node for Monte Carlo Tree Search
+type Node struct {
+	parent       *Node
+	children     []*Node
+	move         engine.BitMove
+	state        engine.BitBoard
+	visits       int
+	wins         float64
+	untriedMoves []engine.BitMove
+	playerTurn   engine.GameSymbol
+	proven       Outcome // Win or Loss for the player who moved to the node once proven, Draw while unknown
+	amafVisits   int     // simulations where the move of the node was played later by the same player (RAVE)
+	amafWins     float64 // results of those simulations for the player of the move
+}
+
+// Result of a search
+type Result struct {
+	Move           engine.Move // best move found
+	Simulations    int         // number of simulations run
+	WinProbability float64     // estimated win probability of the move for the player to move
+	Solved         bool        // true when the outcome of the move was proven by the solver
+	// PrincipalVariation is the line expected by the search: the move, then the most visited replies
+	PrincipalVariation []engine.Move
+	Candidates         []MoveStats   // most visited moves, best first
+	Depth              int           // deepest node reached by the simulations, in plies from the position
+	Duration           time.Duration // time taken by the search
+}
+
+// NodesPerSecond is the speed of the search: each simulation adds a node to the tree,
+// except those reaching the end of the game or a proven position
+func (r Result) NodesPerSecond() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Simulations) / r.Duration.Seconds()
+}
+
+// Runs the Monte Carlo Tree Search algorithm for a given game state and a specified time.
+// returns the best move found, the number of visits and the win probability
+func MonteCarloMove(g *engine.Game, duration time.Duration) (engine.Move, int, float64) {
+	result := Search(context.Background(), g, Config{Duration: duration, Seed: time.Now().UnixNano()})
+	return result.Move, result.Simulations, result.WinProbability
+}
+
+// Search runs the Monte Carlo Tree Search algorithm on the game within the budget of the configuration,
+// or until the context is cancelled. Its random moves come from the seed of the configuration only.
+func Search(ctx context.Context, g *engine.Game, config Config) Result {
+	return NewSearcher(config).Search(ctx, g)
+}
+
+// Create a new node for the Monte Carlo Tree Search and attach it to its parent
+func NewNode(parent *Node, state engine.BitBoard, move engine.BitMove, playerTurn engine.GameSymbol) *Node {
+	node := &Node{
+		parent:       parent,
+		state:        state,
+		move:         move,
+		children:     []*Node{},
+		visits:       0,
+		wins:         0,
+		untriedMoves: state.Moves(nil),
+		playerTurn:   playerTurn,
+	}
+	return node
+}
+
+// Check if the node has untried moves
+func (n *Node) HasUntriedMoves() bool {
+	return len(n.untriedMoves) > 0
+}
+
+// Get the most visited child of the node, used when returning the best move
+func (n *Node) MostVisitedChild() *Node {
+	mostVisits := -1
+	var mostVisitedChild *Node
+
+	for _, child := range n.children {
+		if child.visits > mostVisits {
+			mostVisits = child.visits
+			mostVisitedChild = child
+		}
+	}
+
+	return mostVisitedChild
+}
+
+// Select the best child of the node using the UCT formula with the exploration constant,
+// the children proven lost are skipped unless all are. With a positive RAVE equivalence, the win rate of a child is blended with its all-moves-as-first win rate,
+// whose weight decreases as the child is visited: it is 1/2 after about equivalence/3 visits.
+func (n *Node) UCTSelectChild(exploration, raveEquivalence float64) *Node {
+	bestScore := math.Inf(-1)
+	var bestChild *Node
+
+	for _, child := range n.children {
+		if child.proven == Win {
+			return child
+		}
+		if child.proven == Loss {
+			continue
+		}
+		// Formula balancing exploration (of nodes with good win probabilities) and exploration (of nodes with few visits)
+		value := child.wins / float64(child.visits)
+		if raveEquivalence > 0 && child.amafVisits > 0 {
+			beta := math.Sqrt(raveEquivalence / (3*float64(child.visits) + raveEquivalence))
+			value = (1-beta)*value + beta*child.amafWins/float64(child.amafVisits)
+		}
+		uctValue := value + exploration*math.Sqrt(math.Log(float64(n.visits))/float64(child.visits))
+		if uctValue > bestScore {
+			bestScore = uctValue
+			bestChild = child
+		}
+	}
+
+	if bestChild == nil {
+		return n.MostVisitedChild()
+	}
+	return bestChild
+}
+
+// Take a random move among the moves not yet tried for a specific node
+func (n *Node) GetUntriedMove(rng *rand.Rand) engine.BitMove {
+	index := rng.Intn(len(n.untriedMoves))
+	move := n.untriedMoves[index]
+	n.untriedMoves = append(n.untriedMoves[:index], n.untriedMoves[index+1:]...)
+	return move
+}
+
+// Add a child to a node, a move winning the game is proven
+func (n *Node) AddChild(move engine.BitMove, state engine.BitBoard) *Node {
+	child := NewNode(n, state, move, state.Playing())
+	n.children = append(n.children, child)
+	if state.Winner() == n.playerTurn {
+		child.proven = Win
+		child.propagateProof()
+	}
+	return child
+}
+
+// propagateProof marks the ancestors proven by the outcome of the node:
+// the move before a proven win is lost, and a move whose replies are all proven lost is won
+func (n *Node) propagateProof() {
+	for ; n.parent != nil && n.parent.proven == Draw; n = n.parent {
+		switch n.proven {
+		case Win:
+			n.parent.proven = Loss
+		case Loss:
+			if n.parent.HasUntriedMoves() {
+				return
+			}
+			for _, sibling := range n.parent.children {
+				if sibling.proven != Loss {
+					return
+				}
+			}
+			n.parent.proven = Win
+		default:
+			return
+		}
+	}
+}
+
+// Update the number of visits and wins for a node, used during backpropagation phase
+func (n *Node) Update(result float64) {
+	n.visits++
+	n.wins += result
+}
+
+// Update the all-moves-as-first statistics of the children whose move was played later in the simulation
+// by the player to move at the node, played holding these moves for each player index
+func (n *Node) updateAMAF(played *[2][81]bool, winner engine.GameSymbol, drawReward float64) {
+	moves := &played[playerIndex(n.playerTurn)]
+	score := result(winner, n.playerTurn, drawReward)
+	for _, child := range n.children {
+		if moves[child.move] {
+			child.amafVisits++
+			child.amafWins += score
+		}
+	}
+}
+
+// index of the player in the statistics of the moves played
+func playerIndex(player engine.GameSymbol) int {
+	if player == engine.PLAYER2 {
+		return 1
+	}
+	return 0
+}
+
+// Get the result of a game for a specific player, used during backpropagation phase
+func GetResult(g *engine.BitBoard, playerJustMoved engine.GameSymbol) float64 {
+	return result(g.Winner(), playerJustMoved, DefaultDrawReward)
+}
+
+// result of a game won by winner for a specific player, a draw being worth drawReward
+func result(winner, playerJustMoved engine.GameSymbol, drawReward float64) float64 {
+	if winner == playerJustMoved {
+		return 1
+	} else if winner == engine.NONE {
+		return drawReward
+	}
+	return 0
+}
+
+// winner returns the player winning from the node when it is proven, EMPTY otherwise
+func (n *Node) winner() engine.GameSymbol {
+	switch n.proven {
+	case Win:
+		return n.playerTurn.Opponent()
+	case Loss:
+		return n.playerTurn
+	}
+	return engine.EMPTY
+}
+
+func (n *Node) HasChildren() bool {
+	return len(n.children) > 0
+}
+
+// Get the child reached by the move, nil if the move has not been tried yet
+func (n *Node) child(move engine.BitMove) *Node {
+	for _, child := range n.children {
+		if child.move == move {
+			return child
+		}
+	}
+	return nil
+}
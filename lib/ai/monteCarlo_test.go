@@ -0,0 +1,208 @@
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func initGame() *engine.Game {
+	return engine.NewGame(engine.PLAYER1)
+}
+
+func TestMonteCarloMove(t *testing.T) {
+
+	game := initGame()
+	move, visits, winProbability := MonteCarloMove(game, time.Second)
+
+	if visits < 0 {
+		t.Errorf("Unexpected number of visits: %d", visits)
+	}
+
+	if winProbability < 0 || winProbability > 1 {
+		t.Errorf("Unexpected win probability: %f", winProbability)
+	}
+
+	if !game.IsValidPlay(move.MainBoardRow, move.MainBoardCol) {
+		t.Errorf("Invalid move generated: %v", move)
+	}
+}
+
+func BenchmarkMonteCarloMove(b *testing.B) {
+	game := initGame()
+	simulations := 0
+	for i := 0; i < b.N; i++ {
+		_, visits, _ := MonteCarloMove(game, 100*time.Millisecond)
+		simulations += visits
+	}
+	b.ReportMetric(float64(simulations)/b.Elapsed().Seconds(), "simulations/s")
+}
+
+func TestSearchIsDeterministic(t *testing.T) {
+	game := initGame()
+	for _, notation := range []string{"e5", "e1", "a5"} {
+		move, _ := engine.ParseMove(notation)
+		if err := game.Play(move); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := Config{Iterations: 2000, Seed: 42}
+	first := Search(context.Background(), game, config)
+	if first.Simulations != config.Iterations {
+		t.Errorf("Expected %d simulations, got %d", config.Iterations, first.Simulations)
+	}
+	for i := 0; i < 3; i++ {
+		if result := Search(context.Background(), game, config); !sameResult(result, first) {
+			t.Errorf("Expected the same search result %+v, got %+v", first, result)
+		}
+	}
+}
+
+func TestSearchBudget(t *testing.T) {
+	game := initGame()
+	if result := Search(context.Background(), game, Config{}); result.Simulations != 1 {
+		t.Errorf("Expected a single simulation without budget, got %d", result.Simulations)
+	}
+	start := time.Now()
+	result := Search(context.Background(), game, Config{Iterations: 1 << 30, Duration: 50 * time.Millisecond})
+	if elapsed := time.Since(start); elapsed > time.Second || result.Simulations >= 1<<30 {
+		t.Errorf("Expected the duration to stop the search, got %d simulations in %v", result.Simulations, elapsed)
+	}
+}
+
+func TestConfigString(t *testing.T) {
+	tests := map[Config]string{
+		{Duration: 3 * time.Second}:                       "3s",
+		{Iterations: 10000}:                               "10000 simulations",
+		{Iterations: 500, Duration: time.Second, Seed: 1}: "500 simulations 1s",
+		{Iterations: 500, RAVE: 300}:                      "500 simulations rave",
+	}
+	for config, expected := range tests {
+		if config.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, config.String())
+		}
+	}
+}
+
+func TestConfigParameters(t *testing.T) {
+	if c := (Config{}); c.exploration() != DefaultExploration || c.drawReward() != DefaultDrawReward {
+		t.Errorf("Expected the defaults for the zero configuration, got %v and %v", c.exploration(), c.drawReward())
+	}
+	if c := (Config{Exploration: SetTo(1), DrawReward: SetTo(0.5)}); c.exploration() != 1 || c.drawReward() != 0.5 {
+		t.Errorf("Expected the parameters of the configuration, got %v and %v", c.exploration(), c.drawReward())
+	}
+	if c := (Config{Exploration: SetTo(0), DrawReward: SetTo(0)}); c.exploration() != 0 || c.drawReward() != 0 {
+		t.Errorf("Expected the parameters set to 0 to be kept, got %v and %v", c.exploration(), c.drawReward())
+	}
+}
+
+func TestSearchRAVE(t *testing.T) {
+	game := initGame()
+	for _, rave := range []float64{0, 300} {
+		searcher := NewSearcher(Config{Iterations: 3000, Seed: 1, RAVE: rave})
+		result := searcher.Search(context.Background(), game)
+		if err := game.Clone().Play(result.Move); err != nil {
+			t.Errorf("RAVE %v: expected a legal move, got %v", rave, err)
+		}
+		root := searcher.trees[0].root
+		for _, child := range root.children {
+			switch {
+			case rave == 0 && child.amafVisits != 0:
+				t.Errorf("Expected no all-moves-as-first statistics without RAVE, got %d", child.amafVisits)
+			case rave > 0 && (child.amafVisits < child.visits || child.amafVisits > root.visits):
+				// the move of a child is played first in all its simulations, and at most once per simulation
+				t.Errorf("Expected %d all-moves-as-first visits between %d and %d", child.amafVisits, child.visits, root.visits)
+			}
+		}
+	}
+}
+
+func TestSearchMistakes(t *testing.T) {
+	game := initGame()
+	moves := map[engine.Move]bool{}
+	for seed := int64(0); seed < 20; seed++ {
+		result := Search(context.Background(), game, Config{Iterations: 100, Seed: seed, MistakeRate: 1})
+		if err := game.Clone().Play(result.Move); err != nil {
+			t.Fatalf("Expected a legal mistake, got %v", err)
+		}
+		moves[result.Move] = true
+	}
+	if len(moves) < 5 {
+		t.Errorf("Expected random moves when every move is a mistake, got %v", moves)
+	}
+}
+
+// outcome returns the exact outcome of the position for the player to move
+func outcome(t *testing.T, b engine.BitBoard) Outcome {
+	if b.IsOver() {
+		if b.Winner() == engine.NONE {
+			return Draw
+		}
+		return Loss
+	}
+	solved, err := Solve(context.Background(), b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return solved[0].Outcome
+}
+
+// checkProofs verifies the outcomes proven in the tree with the solver and returns their number
+func checkProofs(t *testing.T, n *Node) int {
+	proofs := 0
+	if n.proven != Draw {
+		proofs++
+		// the outcome of the position is the opposite of the outcome for the player who moved to it
+		if expected := -outcome(t, n.state); n.proven != expected {
+			t.Errorf("Expected the node to be proven %v, got %v", expected, n.proven)
+		}
+	}
+	for _, child := range n.children {
+		proofs += checkProofs(t, child)
+	}
+	return proofs
+}
+
+func TestSearchProofs(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	proofs := 0
+	for i := 0; i < 10; i++ {
+		game := endgame(rng, 14)
+		if game.IsOver() {
+			continue
+		}
+		searcher := NewSearcher(Config{Iterations: 3000, Seed: int64(i)})
+		result := searcher.Search(context.Background(), game)
+		proofs += checkProofs(t, searcher.trees[0].root)
+
+		if solved, _ := Solve(context.Background(), engine.NewBitBoard(game)); solved[0].Outcome == Win {
+			if !result.Solved || result.WinProbability != 1 || result.Simulations >= 3000 {
+				t.Errorf("Expected the search to stop on a proven win, got %+v", result)
+			}
+		}
+	}
+	if proofs == 0 {
+		t.Errorf("Expected proven nodes in the endgames")
+	}
+}
+
+func TestSearchProvenLoss(t *testing.T) {
+	rng := rand.New(rand.NewSource(8))
+	for lost := 0; lost < 3; {
+		game := endgame(rng, 10)
+		if game.IsOver() {
+			continue
+		}
+		if solved, _ := Solve(context.Background(), engine.NewBitBoard(game)); solved[0].Outcome != Loss {
+			continue
+		}
+		lost++
+		// every move is proven lost long before the end of the budget
+		result := Search(context.Background(), game, Config{Iterations: 20000, Seed: 1})
+		if err := game.Play(result.Move); err != nil {
+			t.Errorf("Expected a legal move when every move loses, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// PlayoutPolicy chooses the moves of the simulations of the Monte Carlo Tree Search
+type PlayoutPolicy interface {
+	// Name identifies the policy in configurations
+	Name() string
+	// Choose returns one of the legal moves of the position, which is not over
+	Choose(b *engine.BitBoard, moves []engine.BitMove, rng *rand.Rand) engine.BitMove
+}
+
+// UniformPolicy plays any legal move with the same probability, it is the fastest policy
+type UniformPolicy struct{}
+
+func (UniformPolicy) Name() string {
+	return "uniform"
+}
+
+func (UniformPolicy) Choose(_ *engine.BitBoard, moves []engine.BitMove, rng *rand.Rand) engine.BitMove {
+	return moves[rng.Intn(len(moves))]
+}
+
+// TacticalPolicy wins if it can and blocks if it must: it plays a move winning the game,
+// else blocking a win of the opponent, else winning a mini-board, else blocking one, else a random move
+type TacticalPolicy struct{}
+
+func (TacticalPolicy) Name() string {
+	return "tactical"
+}
+
+func (TacticalPolicy) Choose(b *engine.BitBoard, moves []engine.BitMove, rng *rand.Rand) engine.BitMove {
+	const none = -1
+	winBoard, blockBoard, blockGame := none, none, none
+	for i, move := range moves {
+		switch {
+		case b.WinsGame(move):
+			return move
+		case blockGame == none && b.BlocksGame(move):
+			blockGame = i
+		case winBoard == none && b.WinsBoard(move):
+			winBoard = i
+		case blockBoard == none && b.BlocksBoard(move):
+			blockBoard = i
+		}
+	}
+	for _, choice := range []int{blockGame, winBoard, blockBoard} {
+		if choice != none {
+			return moves[choice]
+		}
+	}
+	return moves[rng.Intn(len(moves))]
+}
+
+// WeightedPolicy draws the moves with probabilities favoring the moves winning or blocking a mini-board
+// and the center cells, and avoiding the moves giving a free choice to the opponent
+type WeightedPolicy struct{}
+
+func (WeightedPolicy) Name() string {
+	return "weighted"
+}
+
+// weights of the moves of WeightedPolicy
+const (
+	baseWeight       = 4
+	winBoardWeight   = 24
+	blockBoardWeight = 12
+	centerWeight     = 2
+)
+
+func (WeightedPolicy) Choose(b *engine.BitBoard, moves []engine.BitMove, rng *rand.Rand) engine.BitMove {
+	var weights [81]int
+	total := 0
+	for i, move := range moves {
+		weight := baseWeight
+		if b.WinsBoard(move) {
+			weight += winBoardWeight
+		} else if b.BlocksBoard(move) {
+			weight += blockBoardWeight
+		}
+		if move%9 == 4 {
+			weight += centerWeight
+		}
+		if b.GivesFreeChoice(move) {
+			weight /= 2
+		}
+		weights[i] = weight
+		total += weight
+	}
+	draw := rng.Intn(total)
+	for i, weight := range weights[:len(moves)] {
+		if draw < weight {
+			return moves[i]
+		}
+		draw -= weight
+	}
+	return moves[len(moves)-1]
+}
+
+// policies lists the playout policies by name
+var policies = map[string]PlayoutPolicy{
+	UniformPolicy{}.Name():  UniformPolicy{},
+	TacticalPolicy{}.Name(): TacticalPolicy{},
+	WeightedPolicy{}.Name(): WeightedPolicy{},
+}
+
+// PolicyNames returns the names of the playout policies, sorted
+func PolicyNames() []string {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParsePolicy returns the playout policy of the name
+func ParsePolicy(name string) (PlayoutPolicy, error) {
+	policy, ok := policies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown playout policy %q, expected one of %v", name, PolicyNames())
+	}
+	return policy, nil
+}
@@ -0,0 +1,80 @@
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPoliciesPlayLegalMoves(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	for _, name := range PolicyNames() {
+		policy, err := ParsePolicy(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 50; i++ {
+			b := engine.NewBitBoard(engine.NewGame(engine.PLAYER1))
+			for !b.IsOver() {
+				moves := b.Moves(nil)
+				move := policy.Choose(&b, moves, rng)
+				if !slices.Contains(moves, move) {
+					t.Fatalf("%s played the illegal move %v", name, move.Move())
+				}
+				b.Play(move)
+			}
+		}
+	}
+	if _, err := ParsePolicy("smart"); err == nil {
+		t.Errorf("Expected an unknown policy to be refused")
+	}
+}
+
+func TestTacticalPolicy(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 50; i++ {
+		b := engine.NewBitBoard(engine.NewGame(engine.PLAYER1))
+		for !b.IsOver() {
+			moves := b.Moves(nil)
+			move := TacticalPolicy{}.Choose(&b, moves, rng)
+			switch {
+			case slices.ContainsFunc(moves, b.WinsGame):
+				if !b.WinsGame(move) {
+					t.Fatalf("Expected a move winning the game, got %v", move.Move())
+				}
+			case slices.ContainsFunc(moves, b.BlocksGame):
+				if !b.BlocksGame(move) {
+					t.Fatalf("Expected a move blocking the game, got %v", move.Move())
+				}
+			case slices.ContainsFunc(moves, b.WinsBoard):
+				if !b.WinsBoard(move) {
+					t.Fatalf("Expected a move winning a mini-board, got %v", move.Move())
+				}
+			case slices.ContainsFunc(moves, b.BlocksBoard):
+				if !b.BlocksBoard(move) {
+					t.Fatalf("Expected a move blocking a mini-board, got %v", move.Move())
+				}
+			}
+			// random moves reach more varied positions
+			b.Play(moves[rng.Intn(len(moves))])
+		}
+	}
+}
+
+func BenchmarkPlayoutPolicies(b *testing.B) {
+	game := initGame()
+	for _, name := range PolicyNames() {
+		policy, _ := ParsePolicy(name)
+		b.Run(name, func(b *testing.B) {
+			simulations := 0
+			for i := 0; i < b.N; i++ {
+				result := Search(context.Background(), game, Config{Duration: 100 * time.Millisecond, Policy: policy})
+				simulations += result.Simulations
+			}
+			b.ReportMetric(float64(simulations)/b.Elapsed().Seconds(), "simulations/s")
+		})
+	}
+}
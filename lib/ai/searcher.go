@@ -0,0 +1,498 @@
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Searcher runs the successive searches of a player in a game.
+// It keeps the trees of its last search and continues from the subtrees of the moves played since,
+// so the statistics gathered on the replies of the opponent are not lost.
+// Each worker of the configuration searches its own tree on its own goroutine (root parallelism),
+// the statistics of the moves of the roots being merged to choose the move.
+// A Searcher must not be used by several goroutines at the same time.
+type Searcher struct {
+	config  Config
+	trees   []*tree       // one tree per worker
+	history []engine.Move // moves played before the position of the roots
+}
+
+// tree is the search of one worker
+type tree struct {
+	rng   *rand.Rand
+	root  *Node // tree of the last search, nil before the first one
+	depth int   // deepest node reached by the last search, in plies from the root
+}
+
+// NewSearcher returns a searcher using the configuration for each search.
+// Its random moves come from the seed of the configuration, so the same searcher plays the same moves
+// when given the same positions in the same order and the configuration has no duration.
+func NewSearcher(config Config) *Searcher {
+	workers := max(config.Workers, 1)
+	if config.Iterations > 0 {
+		// every worker runs at least one simulation
+		workers = min(workers, config.Iterations)
+	}
+	s := &Searcher{config: config, trees: make([]*tree, workers)}
+	for i := range s.trees {
+		s.trees[i] = &tree{rng: rand.New(rand.NewSource(config.Seed + int64(i)))}
+	}
+	return s
+}
+
+// maxPonderSimulations bounds the memory taken by the tree while pondering
+const maxPonderSimulations = 500000
+
+// reported by the results of the searches
+const (
+	maxPrincipalVariation = 10 // plies of the principal variation
+	resultCandidates      = 5  // candidate moves
+)
+
+// Search runs the Monte Carlo Tree Search algorithm on the game within the budget of the configuration,
+// the iterations being shared between the workers.
+// When the context is cancelled first, the search stops and returns the best move found so far,
+// NoMove if none was simulated.
+// Simulations counts the simulations of this search only, those kept from the previous searches are not included.
+//
+// Positions with few open cells are first solved exactly: a winning move is played without search,
+// otherwise the search only chooses among the moves with the best outcome.
+func (s *Searcher) Search(ctx context.Context, g *engine.Game) Result {
+	start := time.Now()
+	result := s.mistake(g, s.search(ctx, g, start))
+	if result.Simulations == 0 {
+		// the move was solved without searching the trees, which may still be those of another position
+		if result.Move != engine.NoMove {
+			result.PrincipalVariation = []engine.Move{result.Move}
+		}
+		result.Duration = time.Since(start)
+		return result
+	}
+	result.PrincipalVariation = s.principalVariation(result.Move)
+	result.Candidates = s.Moves()
+	if len(result.Candidates) > resultCandidates {
+		result.Candidates = result.Candidates[:resultCandidates]
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// search returns the move of the solver or of the simulations, with its statistics.
+// The solver and the simulations share the budget of the search started at start.
+func (s *Searcher) search(ctx context.Context, g *engine.Game, start time.Time) Result {
+	solved := s.solve(ctx, g, start)
+	if len(solved) > 0 && solved[0].Outcome == Win {
+		return Result{Move: solved[0].Move.Move(), WinProbability: 1, Solved: true}
+	}
+	var allowed *[81]bool
+	if len(solved) > 0 {
+		allowed = new([81]bool)
+		for _, move := range solved {
+			allowed[move.Move] = move.Outcome == solved[0].Outcome
+		}
+	}
+
+	simulations := s.run(ctx, g, s.config, start)
+	move, winProbability, proven := s.bestMove(engine.NewBitBoard(g), allowed)
+	depth := 0
+	for _, t := range s.trees {
+		depth = max(depth, t.depth)
+	}
+	return Result{
+		Move:           move,
+		Simulations:    simulations,
+		WinProbability: winProbability,
+		Solved:         allowed != nil || proven,
+		Depth:          depth,
+	}
+}
+
+// mistake replaces the move of the result by a random legal move with the mistake rate of the configuration,
+// its win probability becoming the one estimated by the search, 0 if the move was not explored
+func (s *Searcher) mistake(g *engine.Game, result Result) Result {
+	rng := s.trees[0].rng
+	if s.config.MistakeRate <= 0 || result.Move == engine.NoMove || rng.Float64() >= s.config.MistakeRate {
+		return result
+	}
+	moves := g.PossibleMoves()
+	mistake := engine.NewBitMove(moves[rng.Intn(len(moves))])
+	visits, wins := 0, 0.0
+	for _, t := range s.trees {
+		if t.root == nil {
+			continue
+		}
+		if child := t.root.child(mistake); child != nil {
+			visits += child.visits
+			wins += child.wins
+		}
+	}
+	result.Move, result.WinProbability, result.Solved = mistake.Move(), 0, false
+	if visits > 0 {
+		result.WinProbability = wins / float64(visits)
+	}
+	return result
+}
+
+// solve returns the outcomes of the moves of the game when it has few enough open cells, nil otherwise.
+// The solver is stopped at the end of the duration of the configuration counted from start,
+// the search is then left to the simulations.
+func (s *Searcher) solve(ctx context.Context, g *engine.Game, start time.Time) []SolvedMove {
+	state := engine.NewBitBoard(g)
+	if s.config.SolverCells <= 0 || state.IsOver() || state.OpenCells() > s.config.SolverCells {
+		return nil
+	}
+	if s.config.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, start.Add(s.config.Duration))
+		defer cancel()
+	}
+	solved, err := Solve(ctx, state)
+	if err != nil {
+		return nil
+	}
+	return solved
+}
+
+// Ponder searches the game while the opponent is thinking, until the context is cancelled
+// or the tree holds too many simulations, and returns the number of simulations run.
+// The next search continues from the subtree of the move played by the opponent.
+// Pondering changes the moves found by the next searches, even with an iteration budget.
+func (s *Searcher) Ponder(ctx context.Context, g *engine.Game) int {
+	if g.IsOver() {
+		return 0
+	}
+	config := s.config
+	config.Iterations, config.Duration = maxPonderSimulations*len(s.trees), 0
+	return s.run(ctx, g, config, time.Now())
+}
+
+// run runs the simulations of the budget of the configuration, started at start, on the trees of the workers,
+// starting with their subtrees of the position of the game, and returns the number of simulations
+func (s *Searcher) run(ctx context.Context, g *engine.Game, config Config, start time.Time) int {
+	// the trees are kept when the game continues the last searched position,
+	// they are started again after an undo or in a new game
+	history := g.History()
+	var played []engine.Move
+	continued := len(history) >= len(s.history) && slices.Equal(history[:len(s.history)], s.history)
+	if continued {
+		played = history[len(s.history):]
+	}
+	s.history = history
+	state := engine.NewBitBoard(g)
+
+	simulations := make([]int, len(s.trees))
+	var wg sync.WaitGroup
+	for i, t := range s.trees {
+		workerConfig := config
+		if config.Iterations > 0 {
+			workerConfig.Iterations /= len(s.trees)
+			if i < config.Iterations%len(s.trees) {
+				workerConfig.Iterations++
+			}
+		}
+		if !continued {
+			t.root = nil
+		}
+		t.advance(played, state, g.Playing())
+		wg.Add(1)
+		go func(i int, t *tree) {
+			defer wg.Done()
+			simulations[i] = t.search(ctx, workerConfig, start)
+		}(i, t)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, n := range simulations {
+		total += n
+	}
+	return total
+}
+
+// bestMove merges the statistics of the moves of the roots and returns the most visited move with its win probability,
+// among the allowed moves when allowed is not nil, or a move proven winning by the search.
+// With a temperature, the move is drawn among the explored moves instead, and with threat blindness,
+// the moves only blocking the opponent are sometimes overlooked, like the weaker human players do.
+func (s *Searcher) bestMove(state engine.BitBoard, allowed *[81]bool) (engine.Move, float64, bool) {
+	stats := s.merge()
+	visits, wins, proven := &stats.visits, &stats.wins, &stats.proven
+	// without solver, the legal moves are the moves explored by the search
+	solved := allowed != nil
+	if !solved {
+		allowed = new([81]bool)
+		for move := range allowed {
+			allowed[move] = visits[move] > 0
+		}
+	}
+	// a proven win is played at once, the moves proven lost are only played when all the others are
+	for move := range proven {
+		if allowed[move] && proven[move] == Win {
+			return engine.BitMove(move).Move(), 1, true
+		}
+	}
+	notLost := *allowed
+	for move := range proven {
+		if proven[move] == Loss {
+			notLost[move] = false
+		}
+	}
+	if notLost != [81]bool{} {
+		allowed = &notLost
+	}
+	rng := s.trees[0].rng
+	if s.config.ThreatBlindness > 0 && rng.Float64() < s.config.ThreatBlindness {
+		allowed = overlookThreats(state, allowed)
+	}
+
+	best := engine.BitMove(0)
+	if s.config.Temperature > 0 {
+		best = sampleMove(rng, visits, allowed, s.config.Temperature)
+	} else {
+		for move := range visits {
+			if allowed[move] && (!allowed[best] || visits[move] > visits[best]) {
+				best = engine.BitMove(move)
+			}
+		}
+	}
+	if !allowed[best] {
+		return engine.NoMove, 0, false
+	}
+	if visits[best] == 0 {
+		// a move allowed by the solver but not explored
+		return best.Move(), 0, false
+	}
+	return best.Move(), wins[best] / float64(visits[best]), false
+}
+
+// rootStats are the statistics of the moves of the roots, merged between the workers
+type rootStats struct {
+	visits [81]int
+	wins   [81]float64
+	proven [81]Outcome // Win or Loss for the player to move when proven by a worker, Draw otherwise
+}
+
+func (s *Searcher) merge() *rootStats {
+	stats := &rootStats{}
+	for _, t := range s.trees {
+		if t.root == nil {
+			continue
+		}
+		for _, child := range t.root.children {
+			stats.visits[child.move] += child.visits
+			stats.wins[child.move] += child.wins
+			if child.proven != Draw {
+				stats.proven[child.move] = child.proven
+			}
+		}
+	}
+	return stats
+}
+
+// MoveStats are the statistics of a move of the position of a search
+type MoveStats struct {
+	Move           engine.Move
+	Visits         int     // simulations starting with the move, kept from the previous searches included
+	WinProbability float64 // estimated win probability of the move for the player to move
+	Outcome        Outcome // Win or Loss when the search proved the outcome of the move, Draw otherwise
+}
+
+// Moves returns the statistics of the moves explored by the last search, the most visited first
+func (s *Searcher) Moves() []MoveStats {
+	stats := s.merge()
+	var moves []MoveStats
+	for move, visits := range stats.visits {
+		if visits > 0 {
+			moves = append(moves, MoveStats{
+				Move:           engine.BitMove(move).Move(),
+				Visits:         visits,
+				WinProbability: stats.wins[move] / float64(visits),
+				Outcome:        stats.proven[move],
+			})
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool { return moves[i].Visits > moves[j].Visits })
+	return moves
+}
+
+// principalVariation returns the line starting with the move where both players play the moves
+// most visited by the workers, as expected by the search
+func (s *Searcher) principalVariation(first engine.Move) []engine.Move {
+	if first == engine.NoMove {
+		return nil
+	}
+	var nodes []*Node // nodes of the line in the trees of the workers
+	for _, t := range s.trees {
+		if t.root != nil {
+			nodes = append(nodes, t.root)
+		}
+	}
+	line := []engine.Move{first}
+	move := engine.NewBitMove(first)
+	for len(line) < maxPrincipalVariation {
+		var visits [81]int
+		var next []*Node
+		for _, n := range nodes {
+			if child := n.child(move); child != nil {
+				next = append(next, child)
+				for _, reply := range child.children {
+					visits[reply.move] += reply.visits
+				}
+			}
+		}
+		nodes = next
+		best := -1
+		for reply, n := range visits {
+			if n > 0 && (best < 0 || n > visits[best]) {
+				best = reply
+			}
+		}
+		if best < 0 {
+			break
+		}
+		move = engine.BitMove(best)
+		line = append(line, move.Move())
+	}
+	return line
+}
+
+// overlookThreats removes the moves blocking a mini-board or the game of the opponent without winning a mini-board,
+// as played by a player who did not see the threat, unless they are all the allowed moves
+func overlookThreats(state engine.BitBoard, allowed *[81]bool) *[81]bool {
+	unaware := *allowed
+	for move := range unaware {
+		bitMove := engine.BitMove(move)
+		if unaware[move] && (state.BlocksBoard(bitMove) || state.BlocksGame(bitMove)) && !state.WinsBoard(bitMove) {
+			unaware[move] = false
+		}
+	}
+	if unaware == [81]bool{} {
+		return allowed
+	}
+	return &unaware
+}
+
+// sampleMove draws one of the allowed moves with a probability proportional to visits^(1/temperature):
+// the higher the temperature, the more often the moves found worse by the search are played.
+// The allowed moves that were not explored are only drawn when no allowed move was.
+func sampleMove(rng *rand.Rand, visits *[81]int, allowed *[81]bool, temperature float64) engine.BitMove {
+	var weights [81]float64
+	total, most := 0.0, 0
+	for move, n := range visits {
+		if allowed[move] {
+			most = max(most, n)
+		}
+	}
+	for move, n := range visits {
+		if allowed[move] && n > 0 {
+			// relative to the most visited move, so the weights do not overflow at low temperatures
+			weights[move] = math.Pow(float64(n)/float64(most), 1/temperature)
+			total += weights[move]
+		}
+	}
+	draw := rng.Float64() * total
+	last := engine.BitMove(0)
+	for move, weight := range weights {
+		if weight == 0 {
+			continue
+		}
+		if draw < weight {
+			return engine.BitMove(move)
+		}
+		draw -= weight
+		last = engine.BitMove(move)
+	}
+	if total > 0 {
+		return last
+	}
+	for move := range allowed {
+		if allowed[move] {
+			return engine.BitMove(move)
+		}
+	}
+	return 0
+}
+
+// advance moves the root of the tree to the position reached by the moves played since the last search,
+// a new tree is started when one of the moves was not explored
+func (t *tree) advance(played []engine.Move, state engine.BitBoard, playing engine.GameSymbol) {
+	node := t.root
+	for _, move := range played {
+		if node == nil {
+			break
+		}
+		node = node.child(engine.NewBitMove(move))
+	}
+	if node == nil || node.state != state {
+		node = NewNode(nil, state, 0, playing)
+	}
+	// the rest of the tree can no longer be reached
+	node.parent = nil
+	t.root = node
+}
+
+// search runs the simulations of the budget of the configuration, started at start, on the tree
+// until the context is cancelled or the outcome of the root is proven, and returns their number
+func (t *tree) search(ctx context.Context, config Config, start time.Time) int {
+	policy := config.Policy
+	if policy == nil {
+		policy = UniformPolicy{}
+	}
+	exploration, drawReward := config.exploration(), config.drawReward()
+	possibleMoves := make([]engine.BitMove, 0, 81)
+	rave := config.RAVE > 0
+	var played [2][81]bool // moves of the simulation below the current node, per player index, for RAVE
+	simulations := 0
+	t.depth = 0
+	for ; t.root.proven == Draw && !config.done(simulations, start) && ctx.Err() == nil; simulations++ {
+		node := t.root
+		depth := 0
+		// Selection, stopped by the proven nodes whose winner is known
+		for node.proven == Draw && !node.HasUntriedMoves() && node.HasChildren() && !node.state.IsOver() {
+			node = node.UCTSelectChild(exploration, config.RAVE)
+			depth++
+		}
+		game := node.state
+		// Expansion
+		if node.proven == Draw && node.HasUntriedMoves() && !game.IsOver() {
+			move := node.GetUntriedMove(t.rng)
+			game.Play(move)
+			node = node.AddChild(move, game)
+			depth++
+		}
+		t.depth = max(t.depth, depth)
+		// Simulation
+		if rave {
+			played = [2][81]bool{}
+		}
+		winner := node.winner()
+		if winner == engine.EMPTY {
+			for !game.IsOver() {
+				possibleMoves = game.Moves(possibleMoves[:0])
+				move := policy.Choose(&game, possibleMoves, t.rng)
+				if rave {
+					played[playerIndex(game.Playing())][move] = true
+				}
+				game.Play(move)
+			}
+			winner = game.Winner()
+		}
+		// Backpropagation
+		for node != nil {
+			node.Update(result(winner, node.playerTurn.Opponent(), drawReward))
+			if rave {
+				node.updateAMAF(&played, winner, drawReward)
+				if node.parent != nil {
+					played[playerIndex(node.parent.playerTurn)][node.move] = true
+				}
+			}
+			node = node.parent
+		}
+	}
+	return simulations
+}
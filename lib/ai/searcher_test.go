@@ -0,0 +1,230 @@
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSearcherReusesTree(t *testing.T) {
+	game := initGame()
+	searcher := NewSearcher(Config{Iterations: 5000, Seed: 1})
+	result := searcher.Search(context.Background(), game)
+	if err := game.Play(result.Move); err != nil {
+		t.Fatal(err)
+	}
+	reply := searcher.trees[0].root.child(engine.NewBitMove(result.Move)).MostVisitedChild()
+	if err := game.Play(reply.move.Move()); err != nil {
+		t.Fatal(err)
+	}
+
+	visits := reply.visits
+	result = searcher.Search(context.Background(), game)
+	if root := searcher.trees[0].root; root != reply || root.parent != nil || root.visits != visits+5000 {
+		t.Errorf("Expected the root to move to the reply and keep its %d visits", visits)
+	}
+	if result.Simulations != 5000 {
+		t.Errorf("Expected 5000 simulations, got %d", result.Simulations)
+	}
+}
+
+func TestSearcherRestartsAfterUndo(t *testing.T) {
+	game := initGame()
+	searcher := NewSearcher(Config{Iterations: 1000, Seed: 1})
+	result := searcher.Search(context.Background(), game)
+	if err := game.Play(result.Move); err != nil {
+		t.Fatal(err)
+	}
+	searcher.Search(context.Background(), game)
+
+	game.Undo()
+	searcher.Search(context.Background(), game)
+	if root := searcher.trees[0].root; root.visits != 1000 {
+		t.Errorf("Expected a new tree after undo, got %d visits", root.visits)
+	}
+	searcher.Search(context.Background(), engine.NewGame(engine.PLAYER2))
+	if root := searcher.trees[0].root; root.visits != 1000 || root.playerTurn != engine.PLAYER2 {
+		t.Errorf("Expected a new tree for a new game")
+	}
+}
+
+func TestSearcherWorkers(t *testing.T) {
+	game := initGame()
+	config := Config{Iterations: 4001, Seed: 3, Workers: 4}
+	first := NewSearcher(config).Search(context.Background(), game)
+	if first.Simulations != config.Iterations {
+		t.Errorf("Expected the %d simulations to be shared between the workers, got %d", config.Iterations, first.Simulations)
+	}
+	if result := NewSearcher(config).Search(context.Background(), game); !sameResult(result, first) {
+		t.Errorf("Expected the same search result %+v, got %+v", first, result)
+	}
+	if searcher := NewSearcher(Config{Iterations: 2, Workers: 8}); len(searcher.trees) != 2 {
+		t.Errorf("Expected no more workers than simulations, got %d", len(searcher.trees))
+	}
+}
+
+func BenchmarkSearchWorkers(b *testing.B) {
+	game := initGame()
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			simulations := 0
+			for i := 0; i < b.N; i++ {
+				result := Search(context.Background(), game, Config{Duration: 100 * time.Millisecond, Workers: workers})
+				simulations += result.Simulations
+			}
+			b.ReportMetric(float64(simulations)/b.Elapsed().Seconds(), "simulations/s")
+		})
+	}
+}
+
+func TestSearcherPonder(t *testing.T) {
+	game := initGame()
+	searcher := NewSearcher(Config{Iterations: 1000, Seed: 1})
+	if err := game.Play(searcher.Search(context.Background(), game).Move); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if simulations := searcher.Ponder(ctx, game); simulations == 0 {
+		t.Fatalf("Expected simulations while pondering")
+	}
+	reply := searcher.trees[0].root.MostVisitedChild()
+	if err := game.Play(reply.move.Move()); err != nil {
+		t.Fatal(err)
+	}
+	visits := reply.visits
+	searcher.Search(context.Background(), game)
+	if root := searcher.trees[0].root; root != reply || root.visits != visits+1000 {
+		t.Errorf("Expected the search to continue from the %d visits of the pondering", visits)
+	}
+
+	if simulations := searcher.Ponder(ctx, game); simulations != 0 {
+		t.Errorf("Expected no pondering with a cancelled context, got %d simulations", simulations)
+	}
+}
+
+func TestSearchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if result := Search(ctx, initGame(), Config{Iterations: 1000}); result.Move != engine.NoMove || result.Simulations != 0 {
+		t.Errorf("Expected no move from a cancelled search, got %+v", result)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result := Search(ctx, initGame(), Config{Duration: time.Minute})
+	if elapsed := time.Since(start); elapsed > time.Second || result.Move == engine.NoMove {
+		t.Errorf("Expected the best move found before the cancellation, got %v after %v", result.Move, elapsed)
+	}
+}
+
+func TestSearchTemperature(t *testing.T) {
+	game := initGame()
+	best := Search(context.Background(), game, Config{Iterations: 2000, Seed: 1})
+	if result := Search(context.Background(), game, Config{Iterations: 2000, Seed: 1, Temperature: 0.01}); result.Move != best.Move {
+		t.Errorf("Expected a cold search to play the most visited move %v, got %v", best.Move, result.Move)
+	}
+	moves := map[engine.Move]bool{}
+	for seed := int64(0); seed < 20; seed++ {
+		result := Search(context.Background(), game, Config{Iterations: 500, Seed: seed, Temperature: 5})
+		if err := game.Clone().Play(result.Move); err != nil {
+			t.Fatalf("Expected a legal move, got %v", err)
+		}
+		moves[result.Move] = true
+	}
+	if len(moves) < 5 {
+		t.Errorf("Expected a hot search to play various moves, got %v", moves)
+	}
+}
+
+func TestOverlookThreats(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	threats := 0
+	for threats < 20 {
+		g := endgame(rng, 40+rng.Intn(30))
+		state := engine.NewBitBoard(g)
+		if state.IsOver() {
+			continue
+		}
+		var allowed [81]bool
+		blocking := 0
+		for _, move := range state.Moves(nil) {
+			allowed[move] = true
+			if (state.BlocksBoard(move) || state.BlocksGame(move)) && !state.WinsBoard(move) {
+				blocking++
+			}
+		}
+		unaware := overlookThreats(state, &allowed)
+		for _, move := range state.Moves(nil) {
+			onlyBlocks := (state.BlocksBoard(move) || state.BlocksGame(move)) && !state.WinsBoard(move)
+			if unaware[move] == onlyBlocks && unaware != &allowed {
+				t.Fatalf("Unexpected choice of %v in %v", move.Move(), g)
+			}
+		}
+		if blocking > 0 && unaware != &allowed {
+			threats++
+		}
+	}
+}
+
+func TestSearcherMoves(t *testing.T) {
+	game := initGame()
+	searcher := NewSearcher(Config{Iterations: 2000, Seed: 1, Workers: 2})
+	result := searcher.Search(context.Background(), game)
+	moves := searcher.Moves()
+	if len(moves) != len(game.PossibleMoves()) || moves[0].Move != result.Move {
+		t.Fatalf("Expected the %d moves, the played one first, got %+v", len(game.PossibleMoves()), moves)
+	}
+	total := 0
+	for i, move := range moves {
+		total += move.Visits
+		if i > 0 && move.Visits > moves[i-1].Visits {
+			t.Errorf("Expected the most visited moves first, got %+v", moves)
+		}
+		if move.WinProbability < 0 || move.WinProbability > 1 {
+			t.Errorf("Unexpected win probability %+v", move)
+		}
+	}
+	if total != result.Simulations {
+		t.Errorf("Expected the %d simulations to start with the moves, got %d", result.Simulations, total)
+	}
+}
+
+func TestSearchReport(t *testing.T) {
+	game := initGame()
+	result := NewSearcher(Config{Iterations: 5000, Seed: 2, Workers: 2}).Search(context.Background(), game)
+	pv := result.PrincipalVariation
+	if len(pv) < 2 || pv[0] != result.Move || len(pv) > maxPrincipalVariation {
+		t.Fatalf("Expected a principal variation starting with %v, got %v", result.Move, pv)
+	}
+	line := game.Clone()
+	for _, move := range pv {
+		if err := line.Play(move); err != nil {
+			t.Fatalf("Expected a legal principal variation, got %v: %v", pv, err)
+		}
+	}
+	if result.Depth < len(pv) {
+		t.Errorf("Expected the principal variation of %d plies within the depth %d", len(pv), result.Depth)
+	}
+	if len(result.Candidates) != resultCandidates || result.Candidates[0].Move != result.Move {
+		t.Errorf("Expected the %d best candidates, the played one first, got %+v", resultCandidates, result.Candidates)
+	}
+	if result.Duration <= 0 || result.NodesPerSecond() <= 0 {
+		t.Errorf("Expected the speed of the search, got %v in %v", result.NodesPerSecond(), result.Duration)
+	}
+	if (Result{Simulations: 10}).NodesPerSecond() != 0 {
+		t.Errorf("Expected no speed without duration")
+	}
+}
+
+// sameResult compares the results of two searches, whose durations differ
+func sameResult(a, b Result) bool {
+	a.Duration, b.Duration = 0, 0
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,173 @@
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"sort"
+)
+
+// DefaultSolverCells is the number of open cells below which the positions are solved exactly by default
+const DefaultSolverCells = 20
+
+// Outcome is the exact result of a position or a move for the player to move, with a perfect play of both sides
+type Outcome int8
+
+const (
+	Loss Outcome = -1
+	Draw Outcome = 0
+	Win  Outcome = 1
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Loss:
+		return "loss"
+	case Win:
+		return "win"
+	}
+	return "draw"
+}
+
+// SolvedMove is a legal move with its exact outcome for the player playing it
+type SolvedMove struct {
+	Move    engine.BitMove
+	Outcome Outcome
+}
+
+// bounds of the values stored in the transposition table
+const (
+	exact uint8 = iota
+	lowerBound
+	upperBound
+)
+
+// solverEntry is the result of the search of a position
+type solverEntry struct {
+	value Outcome
+	bound uint8
+	best  engine.BitMove // move of the value, tried first when the position is searched again
+}
+
+// solver is a negamax search with alpha-beta pruning, a transposition table and move ordering
+type solver struct {
+	ctx     context.Context
+	table   map[engine.BitBoard]solverEntry
+	nodes   int
+	stopped bool // true once the context is found cancelled, the search then unwinds without visiting positions
+}
+
+// the context is checked every checkInterval positions
+const checkInterval = 1 << 12
+
+// Solve returns the exact outcome of every legal move of the position, best moves first.
+// Its cost grows very fast with the number of open cells of the position, so it is meant for the end of the game.
+// It returns the error of the context when it is cancelled first.
+func Solve(ctx context.Context, b engine.BitBoard) ([]SolvedMove, error) {
+	s := &solver{ctx: ctx, table: make(map[engine.BitBoard]solverEntry)}
+	moves := b.Moves(nil)
+	solved := make([]SolvedMove, len(moves))
+	for i, move := range moves {
+		child := b
+		child.Play(move)
+		solved[i] = SolvedMove{Move: move, Outcome: -s.negamax(&child, Loss, Win)}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(solved, func(i, j int) bool { return solved[i].Outcome > solved[j].Outcome })
+	return solved, nil
+}
+
+// negamax returns the outcome of the position for the player to move, if it lies between alpha and beta,
+// or a bound of the outcome beyond them. It returns Draw when the search is cancelled.
+func (s *solver) negamax(b *engine.BitBoard, alpha, beta Outcome) Outcome {
+	if b.IsOver() {
+		if b.Winner() == engine.NONE {
+			return Draw
+		}
+		// the winner is the player who just moved
+		return Loss
+	}
+	s.nodes++
+	if s.nodes%checkInterval == 0 && s.ctx.Err() != nil {
+		s.stopped = true
+	}
+	if s.stopped {
+		return Draw
+	}
+
+	entry, found := s.table[*b]
+	if found {
+		switch {
+		case entry.bound == exact:
+			return entry.value
+		case entry.bound == lowerBound:
+			alpha = max(alpha, entry.value)
+		case entry.bound == upperBound:
+			beta = min(beta, entry.value)
+		}
+		if alpha >= beta {
+			return entry.value
+		}
+	}
+
+	var buffer [81]engine.BitMove
+	moves := orderMoves(b, b.Moves(buffer[:0]), entry.best, found)
+	originalAlpha := alpha
+	best, bestMove := Loss-1, moves[0]
+	for _, move := range moves {
+		child := *b
+		child.Play(move)
+		value := -s.negamax(&child, -beta, -alpha)
+		if value > best {
+			best, bestMove = value, move
+		}
+		alpha = max(alpha, value)
+		if alpha >= beta {
+			break
+		}
+	}
+	if s.ctx.Err() != nil {
+		// the values of a cancelled search are wrong
+		return Draw
+	}
+
+	bound := exact
+	if best <= originalAlpha {
+		bound = upperBound
+	} else if best >= beta {
+		bound = lowerBound
+	}
+	s.table[*b] = solverEntry{value: best, bound: bound, best: bestMove}
+	return best
+}
+
+// orderMoves puts first the moves most likely to be good, so the pruning happens early:
+// the best move of a previous search, the moves winning a mini-board, then the moves not giving a free choice
+// to the opponent
+func orderMoves(b *engine.BitBoard, moves []engine.BitMove, best engine.BitMove, hasBest bool) []engine.BitMove {
+	var priorities [81]int
+	for i, move := range moves {
+		priority := 0
+		if hasBest && move == best {
+			priority = 3
+		} else if b.WinsBoard(move) {
+			priority = 2
+		} else {
+			child := *b
+			child.Play(move)
+			if !child.FreeChoice() {
+				priority = 1
+			}
+		}
+		priorities[i] = priority
+	}
+	// insertion sort, the lists are short
+	for i := 1; i < len(moves); i++ {
+		for j := i; j > 0 && priorities[j] > priorities[j-1]; j-- {
+			moves[j], moves[j-1] = moves[j-1], moves[j]
+			priorities[j], priorities[j-1] = priorities[j-1], priorities[j]
+		}
+	}
+	return moves
+}
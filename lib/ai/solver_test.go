@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// endgame plays random moves until the game has at most cells open cells, it may be over
+func endgame(rng *rand.Rand, cells int) *engine.Game {
+	g := engine.NewGame(engine.PLAYER1)
+	for b := engine.NewBitBoard(g); !b.IsOver() && b.OpenCells() > cells; b = engine.NewBitBoard(g) {
+		moves := g.PossibleMoves()
+		if err := g.Play(moves[rng.Intn(len(moves))]); err != nil {
+			panic(err)
+		}
+	}
+	return g
+}
+
+// bruteForce is the plain search the solver must agree with
+func bruteForce(b engine.BitBoard) Outcome {
+	if b.IsOver() {
+		if b.Winner() == engine.NONE {
+			return Draw
+		}
+		return Loss
+	}
+	best := Loss
+	for _, move := range b.Moves(nil) {
+		child := b
+		child.Play(move)
+		best = max(best, -bruteForce(child))
+	}
+	return best
+}
+
+func TestSolveMatchesMinimax(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 30; i++ {
+		b := engine.NewBitBoard(endgame(rng, 10))
+		if b.IsOver() {
+			continue
+		}
+		solved, err := Solve(context.Background(), b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(solved) != len(b.Moves(nil)) {
+			t.Fatalf("Expected an outcome for each of the %d moves, got %d", len(b.Moves(nil)), len(solved))
+		}
+		for j, move := range solved {
+			child := b
+			child.Play(move.Move)
+			if expected := -bruteForce(child); move.Outcome != expected {
+				t.Errorf("Expected %v for %v, got %v", expected, move.Move.Move(), move.Outcome)
+			}
+			if j > 0 && move.Outcome > solved[j-1].Outcome {
+				t.Errorf("Expected the best moves first")
+			}
+		}
+	}
+}
+
+func TestSolveCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b := engine.NewBitBoard(endgame(rand.New(rand.NewSource(1)), 30))
+	if _, err := Solve(ctx, b); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestSearcherUsesSolver(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	wins, others := 0, 0
+	for wins < 5 || others < 5 {
+		game := endgame(rng, 12)
+		if game.IsOver() {
+			continue
+		}
+		solved, err := Solve(context.Background(), engine.NewBitBoard(game))
+		if err != nil {
+			t.Fatal(err)
+		}
+		outcomes := map[engine.Move]Outcome{}
+		for _, move := range solved {
+			outcomes[move.Move.Move()] = move.Outcome
+		}
+
+		result := NewSearcher(Config{Iterations: 100, Seed: 1, SolverCells: 12}).Search(context.Background(), game)
+		if !result.Solved || outcomes[result.Move] != solved[0].Outcome {
+			t.Errorf("Expected a move with the outcome %v, got %v %v", solved[0].Outcome, result.Move, outcomes[result.Move])
+		}
+		if solved[0].Outcome == Win {
+			wins++
+			if result.Simulations != 0 {
+				t.Errorf("Expected a proven win to be played without search")
+			}
+		} else {
+			others++
+		}
+	}
+}
+
+func TestSearcherSharesDurationWithSolver(t *testing.T) {
+	// the solver cannot finish with so many open cells, the simulations only get what it leaves of the duration
+	game := endgame(rand.New(rand.NewSource(3)), 50)
+	config := Config{Duration: 200 * time.Millisecond, Seed: 1, SolverCells: 81}
+	start := time.Now()
+	result := NewSearcher(config).Search(context.Background(), game)
+	if elapsed := time.Since(start); elapsed > config.Duration*3/2 || result.Move == engine.NoMove {
+		t.Errorf("Expected a move within the duration %v, got %v after %v", config.Duration, result.Move, elapsed)
+	}
+}
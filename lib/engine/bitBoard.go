@@ -0,0 +1,254 @@
+package engine
+
+import "math/bits"
+
+// fullMask has one bit set for each of the nine cells of a mini-board (or mini-boards of the game)
+const fullMask uint16 = 1<<9 - 1
+
+// winLines holds the eight three-in-a-row masks of a 3x3 board, cell index being row*3+col
+var winLines = [8]uint16{
+	0007, 0070, 0700, // rows
+	0111, 0222, 0444, // columns
+	0421, 0124, // diagonals
+}
+
+// isWinningMask tells for each 9-bit mask if it contains a line, it is shared by mini-boards and the main board
+var isWinningMask [1 << 9]bool
+
+func init() {
+	for mask := range isWinningMask {
+		for _, line := range winLines {
+			if uint16(mask)&line == line {
+				isWinningMask[mask] = true
+				break
+			}
+		}
+	}
+}
+
+// BitMove is a move encoded as board*9+cell, both indexes being row*3+col
+type BitMove uint8
+
+// NewBitMove encodes a move
+func NewBitMove(move Move) BitMove {
+	board := move.MainBoardRow*BoardRowLength + move.MainBoardCol
+	cell := move.MiniBoardRow*BoardRowLength + move.MiniBoardCol
+	return BitMove(board*9 + cell)
+}
+
+// Move decodes the move
+func (m BitMove) Move() Move {
+	board, cell := int(m)/9, int(m)%9
+	return Move{
+		MainBoardRow: board / BoardRowLength,
+		MainBoardCol: board % BoardRowLength,
+		MiniBoardRow: cell / BoardRowLength,
+		MiniBoardCol: cell % BoardRowLength,
+	}
+}
+
+// BitBoard is a compact copy of a Game position made of bit masks.
+// It is cheap to copy and to play on, which makes it the position used by the AI playouts.
+type BitBoard struct {
+	cells   [2][9]uint16 // cells taken in each mini-board, per player (0 for PLAYER1, 1 for PLAYER2)
+	macro   [2]uint16    // mini-boards won, per player
+	decided uint16       // mini-boards won or full
+	forced  int8         // mini-board receiving the next move, -1 when any open mini-board can
+	player  uint8        // index of the player to move
+	result  GameSymbol   // winner, NONE for a draw or EMPTY if the game has not ended yet
+}
+
+// symbols indexed by player index
+var bitBoardSymbols = [2]GameSymbol{PLAYER1, PLAYER2}
+
+// NewBitBoard converts a game position
+func NewBitBoard(g *Game) BitBoard {
+	b := BitBoard{forced: -1, result: g.Winner()}
+	if g.Playing() == PLAYER2 {
+		b.player = 1
+	}
+	for i := 0; i < BoardRowLength; i++ {
+		for j := 0; j < BoardRowLength; j++ {
+			board := i*BoardRowLength + j
+			for k := 0; k < BoardRowLength; k++ {
+				for l := 0; l < BoardRowLength; l++ {
+					bit := uint16(1) << (k*BoardRowLength + l)
+					switch g.board[i][j].Board[k][l] {
+					case PLAYER1:
+						b.cells[0][board] |= bit
+					case PLAYER2:
+						b.cells[1][board] |= bit
+					}
+				}
+			}
+			switch g.board[i][j].Winner {
+			case PLAYER1:
+				b.macro[0] |= 1 << board
+				b.decided |= 1 << board
+			case PLAYER2:
+				b.macro[1] |= 1 << board
+				b.decided |= 1 << board
+			case NONE:
+				b.decided |= 1 << board
+			}
+		}
+	}
+	if last := g.LastMove(); last.MiniBoardRow != -1 {
+		target := last.MiniBoardRow*BoardRowLength + last.MiniBoardCol
+		if b.decided&(1<<target) == 0 {
+			b.forced = int8(target)
+		}
+	}
+	return b
+}
+
+// Playing returns the symbol of the player to move
+func (b *BitBoard) Playing() GameSymbol {
+	return bitBoardSymbols[b.player]
+}
+
+// Winner returns the winner of the game, NONE for a draw or EMPTY if the game has not ended yet
+func (b *BitBoard) Winner() GameSymbol {
+	return b.result
+}
+
+// IsOver tells if the game has ended
+func (b *BitBoard) IsOver() bool {
+	return b.result != EMPTY
+}
+
+// Play applies a legal move for the player to move and gives the turn to the opponent
+func (b *BitBoard) Play(m BitMove) {
+	board, cell := m/9, m%9
+	p := b.player
+	b.cells[p][board] |= 1 << cell
+	if isWinningMask[b.cells[p][board]] {
+		b.macro[p] |= 1 << board
+		b.decided |= 1 << board
+		if isWinningMask[b.macro[p]] {
+			b.result = bitBoardSymbols[p]
+		}
+	} else if b.cells[0][board]|b.cells[1][board] == fullMask {
+		b.decided |= 1 << board
+		// a line of drawn mini-boards ends the game as a draw
+		if isWinningMask[b.decided&^(b.macro[0]|b.macro[1])] {
+			b.result = NONE
+		}
+	}
+	if b.result == EMPTY && b.decided == fullMask {
+		b.result = NONE
+	}
+	if b.decided&(1<<cell) == 0 {
+		b.forced = int8(cell)
+	} else {
+		b.forced = -1
+	}
+	b.player ^= 1
+}
+
+// OpenCells counts the empty cells of the mini-boards still open, the cells that can be played until the end of the game
+func (b *BitBoard) OpenCells() int {
+	if b.result != EMPTY {
+		return 0
+	}
+	count := 0
+	for open := ^b.decided & fullMask; open != 0; open &= open - 1 {
+		board := bits.TrailingZeros16(open)
+		count += 9 - bits.OnesCount16(b.cells[0][board]|b.cells[1][board])
+	}
+	return count
+}
+
+// WinsBoard tells if the legal move m wins its mini-board for the player to move
+func (b *BitBoard) WinsBoard(m BitMove) bool {
+	board, cell := m/9, m%9
+	return isWinningMask[b.cells[b.player][board]|1<<cell]
+}
+
+// WinsGame tells if the legal move m wins the game for the player to move
+func (b *BitBoard) WinsGame(m BitMove) bool {
+	return b.WinsBoard(m) && isWinningMask[b.macro[b.player]|1<<(m/9)]
+}
+
+// BlocksBoard tells if the legal move m takes the cell the opponent needs to win its mini-board
+func (b *BitBoard) BlocksBoard(m BitMove) bool {
+	board, cell := m/9, m%9
+	return isWinningMask[b.cells[b.player^1][board]|1<<cell]
+}
+
+// BlocksGame tells if the legal move m takes the cell the opponent needs to win its mini-board and the game with it
+func (b *BitBoard) BlocksGame(m BitMove) bool {
+	return b.BlocksBoard(m) && isWinningMask[b.macro[b.player^1]|1<<(m/9)]
+}
+
+// GivesFreeChoice tells if the legal move m sends the opponent to a decided mini-board,
+// letting it play in any open mini-board
+func (b *BitBoard) GivesFreeChoice(m BitMove) bool {
+	board, cell := m/9, m%9
+	decided := b.decided
+	if b.WinsBoard(m) || (b.cells[0][board]|b.cells[1][board]|1<<cell) == fullMask {
+		decided |= 1 << board
+	}
+	return decided&(1<<cell) != 0
+}
+
+// FreeChoice tells if the player to move can play in any open mini-board
+func (b *BitBoard) FreeChoice() bool {
+	return b.forced < 0
+}
+
+// Cells returns the cells of the mini-board taken by the player, as a 9-bit mask indexed by row*3+col
+func (b *BitBoard) Cells(board int, player GameSymbol) uint16 {
+	return b.cells[playerIndex(player)][board]
+}
+
+// Boards returns the mini-boards won by the player, as a 9-bit mask indexed by row*3+col
+func (b *BitBoard) Boards(player GameSymbol) uint16 {
+	return b.macro[playerIndex(player)]
+}
+
+// Decided returns the mini-boards won or full, as a 9-bit mask indexed by row*3+col
+func (b *BitBoard) Decided() uint16 {
+	return b.decided
+}
+
+func playerIndex(player GameSymbol) int {
+	if player == PLAYER2 {
+		return 1
+	}
+	return 0
+}
+
+// Threats counts the lines of a 3x3 mask holding two cells of own and none of blocked,
+// the lines that own completes by taking a single cell
+func Threats(own, blocked uint16) int {
+	count := 0
+	for _, line := range winLines {
+		if own&line != line && bits.OnesCount16(own&line) == 2 && blocked&line == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// Moves appends the legal moves to moves and returns the extended slice
+func (b *BitBoard) Moves(moves []BitMove) []BitMove {
+	if b.result != EMPTY {
+		return moves
+	}
+	if b.forced >= 0 {
+		return b.appendBoardMoves(moves, int(b.forced))
+	}
+	for open := ^b.decided & fullMask; open != 0; open &= open - 1 {
+		moves = b.appendBoardMoves(moves, bits.TrailingZeros16(open))
+	}
+	return moves
+}
+
+// append the empty cells of a mini-board
+func (b *BitBoard) appendBoardMoves(moves []BitMove, board int) []BitMove {
+	for empty := ^(b.cells[0][board] | b.cells[1][board]) & fullMask; empty != 0; empty &= empty - 1 {
+		moves = append(moves, BitMove(board*9+bits.TrailingZeros16(empty)))
+	}
+	return moves
+}
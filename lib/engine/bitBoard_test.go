@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// sortedMoves returns the legal moves of a game encoded as BitMove in increasing order
+func sortedMoves(g *Game) []BitMove {
+	moves := make([]BitMove, 0)
+	for _, m := range g.PossibleMoves() {
+		moves = append(moves, NewBitMove(m))
+	}
+	sort.Slice(moves, func(i, j int) bool { return moves[i] < moves[j] })
+	return moves
+}
+
+func TestBitMove(t *testing.T) {
+	for i := 0; i < 81; i++ {
+		if NewBitMove(BitMove(i).Move()) != BitMove(i) {
+			t.Errorf("Encoding of move %d is not reversible", i)
+		}
+	}
+}
+
+func TestBitBoardFollowsGame(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		game := NewGame(PLAYER1)
+		bitBoard := NewBitBoard(game)
+		for !game.IsOver() {
+			expected := sortedMoves(game)
+			moves := bitBoard.Moves(nil)
+			sort.Slice(moves, func(i, j int) bool { return moves[i] < moves[j] })
+			if len(moves) != len(expected) {
+				t.Fatalf("Expected %d moves, got %d", len(expected), len(moves))
+			}
+			for j := range moves {
+				if moves[j] != expected[j] {
+					t.Fatalf("Expected move %v, got %v", expected[j], moves[j])
+				}
+			}
+			if bitBoard.Playing() != game.Playing() {
+				t.Fatalf("Expected %q to play, got %q", game.Playing(), bitBoard.Playing())
+			}
+
+			if open := openCells(game); bitBoard.OpenCells() != open {
+				t.Fatalf("Expected %d open cells, got %d", open, bitBoard.OpenCells())
+			}
+			if _, _, forced := game.ForcedBoard(); bitBoard.FreeChoice() == forced {
+				t.Fatalf("Expected free choice %v", !forced)
+			}
+
+			move := moves[rng.Intn(len(moves))]
+			winsBoard, player := bitBoard.WinsBoard(move), game.Playing()
+			winsGame, freeChoice := bitBoard.WinsGame(move), bitBoard.GivesFreeChoice(move)
+			blocksBoard, blocksGame := bitBoard.BlocksBoard(move), bitBoard.BlocksGame(move)
+			opponent := bitBoard
+			opponent.player ^= 1
+			if opponent.WinsBoard(move) != blocksBoard || opponent.WinsGame(move) != blocksGame {
+				t.Fatalf("Expected the blocks of %v to be the wins of the opponent", move.Move())
+			}
+			mustPlay(t, game, move.Move())
+			if won := game.MiniBoardWinner(move.Move().MainBoardRow, move.Move().MainBoardCol) == player; won != winsBoard {
+				t.Fatalf("Expected WinsBoard %v for %v", won, move.Move())
+			}
+			if won := game.Winner() == player; won != winsGame {
+				t.Fatalf("Expected WinsGame %v for %v", won, move.Move())
+			}
+			if _, _, forced := game.ForcedBoard(); !game.IsOver() && freeChoice == forced {
+				t.Fatalf("Expected GivesFreeChoice %v for %v", !forced, move.Move())
+			}
+			bitBoard.Play(move)
+			if NewBitBoard(game) != bitBoard {
+				t.Fatalf("Converted game differs from the played bit board")
+			}
+		}
+		if bitBoard.Winner() != game.Winner() {
+			t.Errorf("Expected winner %q, got %q", game.Winner(), bitBoard.Winner())
+		}
+	}
+}
+
+func TestBitBoardDrawnLine(t *testing.T) {
+	// random games until one ends by a line of drawn mini-boards, which is rare
+	rng := rand.New(rand.NewSource(1))
+	var moves []BitMove
+	for {
+		moves = moves[:0]
+		bitBoard := NewBitBoard(NewGame(PLAYER1))
+		for !bitBoard.IsOver() {
+			legal := bitBoard.Moves(nil)
+			move := legal[rng.Intn(len(legal))]
+			bitBoard.Play(move)
+			moves = append(moves, move)
+		}
+		if bitBoard.Winner() == NONE && bitBoard.decided != fullMask {
+			break
+		}
+	}
+
+	game := NewGame(PLAYER1)
+	for i, move := range moves {
+		if game.IsOver() {
+			t.Fatalf("Expected the game to go on until move %d, it ended at move %d", len(moves), i)
+		}
+		mustPlay(t, game, move.Move())
+	}
+	if game.Winner() != NONE {
+		t.Errorf("Expected a draw by the line of drawn mini-boards, got %q", game.Winner())
+	}
+}
+
+func BenchmarkGamePlayout(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		game := NewGame(PLAYER1)
+		for !game.IsOver() {
+			moves := game.PossibleMoves()
+			game.play(moves[rng.Intn(len(moves))])
+		}
+	}
+}
+
+func BenchmarkBitBoardPlayout(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	moves := make([]BitMove, 0, 81)
+	for i := 0; i < b.N; i++ {
+		game := NewBitBoard(NewGame(PLAYER1))
+		for !game.IsOver() {
+			moves = game.Moves(moves[:0])
+			game.Play(moves[rng.Intn(len(moves))])
+		}
+	}
+}
+
+// openCells counts the empty cells of the undecided mini-boards of a game
+func openCells(g *Game) int {
+	if g.IsOver() {
+		return 0
+	}
+	count := 0
+	for i := 0; i < BoardRowLength; i++ {
+		for j := 0; j < BoardRowLength; j++ {
+			if g.MiniBoardWinner(i, j) != EMPTY {
+				continue
+			}
+			for k := 0; k < BoardRowLength; k++ {
+				for l := 0; l < BoardRowLength; l++ {
+					if g.Cell(Move{i, j, k, l}) == EMPTY {
+						count++
+					}
+				}
+			}
+		}
+	}
+	return count
+}
+
+func TestThreats(t *testing.T) {
+	tests := []struct {
+		own, blocked uint16
+		expected     int
+	}{
+		{0, 0, 0},
+		{0003, 0, 1},    // two cells of the top row
+		{0003, 0004, 0}, // blocked by the third cell
+		{0021, 0, 1},    // diagonal
+		{0007, 0, 0},    // complete line
+		{0025, 0, 3},    // top corners and center: top row and both diagonals
+		{0025, 0600, 2}, // the bottom right corner blocks a diagonal
+	}
+	for _, test := range tests {
+		if threats := Threats(test.own, test.blocked); threats != test.expected {
+			t.Errorf("Expected %d threats for %03o blocked by %03o, got %d", test.expected, test.own, test.blocked, threats)
+		}
+	}
+}
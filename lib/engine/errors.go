@@ -0,0 +1,29 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errors returned by Play, wrapped in a MoveError
+var (
+	ErrGameOver     = errors.New("the game is over")
+	ErrOutOfRange   = errors.New("coordinates out of range")
+	ErrBoardDecided = errors.New("the mini-board is already decided")
+	ErrWrongBoard   = errors.New("the move must be played in the forced mini-board")
+	ErrOccupied     = errors.New("the cell is already taken")
+)
+
+// MoveError is returned when a move is refused, the reason can be tested with errors.Is
+type MoveError struct {
+	Move Move
+	Err  error
+}
+
+func (e *MoveError) Error() string {
+	return fmt.Sprintf("invalid move %v: %v", e.Move, e.Err)
+}
+
+func (e *MoveError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,304 @@
+// Package engine implements the rules of ultimate tic-tac-toe.
+//
+// It holds the position of a game, applies moves, generates the legal moves and
+// reports the result. It has no dependency on the graphical client so that the
+// GUI, the AI and the tests can all build on it.
+package engine
+
+const BoardRowLength = 3
+
+// GameSymbol determine the symbols contained in the game
+type GameSymbol rune
+
+// enum determining the symbols contained in the game
+const (
+	PLAYER1 GameSymbol = 'O'
+	PLAYER2 GameSymbol = 'X'
+	EMPTY   GameSymbol = ' ' // for empty cell, or a game/mini-board without result yet
+	NONE    GameSymbol = 0   // for a draw
+)
+
+// Opponent returns the symbol of the other player
+func (s GameSymbol) Opponent() GameSymbol {
+	if s == PLAYER1 {
+		return PLAYER2
+	}
+	return PLAYER1
+}
+
+// Game is the position of an ultimate tic-tac-toe game
+type Game struct {
+	playing  GameSymbol                                // current player as a symbol
+	board    [BoardRowLength][BoardRowLength]MiniBoard // the game board
+	round    int                                       // current round index
+	win      GameSymbol                                // winner symbol or EMPTY if the game has not ended yet
+	lastPlay Move                                      // last play coordinates
+	history  []historyEntry                            // moves played, in order
+	future   []Move                                    // moves taken back, the next one to replay being the last
+}
+
+// historyEntry is a move played and the state it replaced, so it can be taken back
+type historyEntry struct {
+	move     Move                                       // the move played
+	lastPlay Move                                       // the previous move, giving the forced mini-board
+	winners  [BoardRowLength][BoardRowLength]GameSymbol // winners of the mini-boards before the move
+	win      GameSymbol                                 // winner of the game before the move
+}
+
+// NewGame returns an empty game where first plays the first move
+func NewGame(first GameSymbol) *Game {
+	g := &Game{
+		playing:  first,
+		win:      EMPTY,
+		lastPlay: NoMove,
+	}
+	for i := 0; i < BoardRowLength; i++ {
+		for j := 0; j < BoardRowLength; j++ {
+			g.board[i][j] = MiniBoard{Board: [3][3]GameSymbol{
+				{EMPTY, EMPTY, EMPTY},
+				{EMPTY, EMPTY, EMPTY},
+				{EMPTY, EMPTY, EMPTY}},
+				Winner: EMPTY}
+		}
+	}
+	return g
+}
+
+// Playing returns the symbol of the player to move
+func (g *Game) Playing() GameSymbol {
+	return g.playing
+}
+
+// Round returns the number of moves played so far
+func (g *Game) Round() int {
+	return g.round
+}
+
+// Winner returns the winner of the game, NONE for a draw or EMPTY if the game has not ended yet
+func (g *Game) Winner() GameSymbol {
+	return g.win
+}
+
+// IsOver tells if the game has ended
+func (g *Game) IsOver() bool {
+	return g.win != EMPTY
+}
+
+// LastMove returns the last move played, NoMove at the start of the game
+func (g *Game) LastMove() Move {
+	return g.lastPlay
+}
+
+// Cell returns the symbol of the cell at the given coordinates
+func (g *Game) Cell(coordinates Move) GameSymbol {
+	return g.board[coordinates.MainBoardRow][coordinates.MainBoardCol].Board[coordinates.MiniBoardRow][coordinates.MiniBoardCol]
+}
+
+// set the symbol of the cell at the given coordinates
+func (g *Game) setCell(coordinates Move, value GameSymbol) {
+	g.board[coordinates.MainBoardRow][coordinates.MainBoardCol].
+		Board[coordinates.MiniBoardRow][coordinates.MiniBoardCol] = value
+}
+
+// MiniBoardWinner returns the winner of a mini-board, NONE for a draw or EMPTY if it is still open
+func (g *Game) MiniBoardWinner(row, col int) GameSymbol {
+	return g.board[row][col].Winner
+}
+
+// IsValidPlay determines if the mini-board at row, col can receive the next move
+func (g *Game) IsValidPlay(row, col int) bool {
+	if g.lastPlay.MiniBoardRow == -1 {
+		// the first move of the game is always valid
+		return true
+	} else if g.board[g.lastPlay.MiniBoardRow][g.lastPlay.MiniBoardCol].Winner != EMPTY {
+		// when the last move complete a mini-game, the next move can be played anywhere
+		return true
+	} else if row == g.lastPlay.MiniBoardRow && col == g.lastPlay.MiniBoardCol {
+		// the next move must be played in the mini-game corresponding to the last move position
+		return true
+	}
+	return false
+}
+
+// PossibleMoves returns all the legal moves for the current state of the game
+func (g *Game) PossibleMoves() []Move {
+	possibleMoves := make([]Move, 0)
+	if g.win != EMPTY {
+		return possibleMoves
+	}
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if g.IsValidPlay(i, j) && g.board[i][j].Winner == EMPTY {
+				for k := 0; k < 3; k++ {
+					for l := 0; l < 3; l++ {
+						coord := Move{MainBoardRow: i, MainBoardCol: j, MiniBoardRow: k, MiniBoardCol: l}
+						if g.Cell(coord) == EMPTY {
+							possibleMoves = append(possibleMoves, coord)
+						}
+					}
+				}
+			}
+		}
+	}
+	return possibleMoves
+}
+
+// Play applies the move for the current player and gives the turn to the opponent.
+// A move that is not one of PossibleMoves is refused with a *MoveError and the game is left unchanged.
+// Moves taken back with Undo can no longer be replayed.
+func (g *Game) Play(move Move) error {
+	if err := g.checkPlay(move); err != nil {
+		return &MoveError{Move: move, Err: err}
+	}
+	g.future = g.future[:0]
+	g.play(move)
+	return nil
+}
+
+// checkPlay returns the reason why the move cannot be played, nil if it is legal
+func (g *Game) checkPlay(move Move) error {
+	if g.win != EMPTY {
+		return ErrGameOver
+	}
+	for _, coordinate := range []int{move.MainBoardRow, move.MainBoardCol, move.MiniBoardRow, move.MiniBoardCol} {
+		if coordinate < 0 || coordinate >= BoardRowLength {
+			return ErrOutOfRange
+		}
+	}
+	if g.board[move.MainBoardRow][move.MainBoardCol].Winner != EMPTY {
+		return ErrBoardDecided
+	}
+	if !g.IsValidPlay(move.MainBoardRow, move.MainBoardCol) {
+		return ErrWrongBoard
+	}
+	if g.Cell(move) != EMPTY {
+		return ErrOccupied
+	}
+	return nil
+}
+
+// play applies the move and records it in the history
+func (g *Game) play(move Move) {
+	entry := historyEntry{move: move, lastPlay: g.lastPlay, win: g.win}
+	for i := 0; i < BoardRowLength; i++ {
+		for j := 0; j < BoardRowLength; j++ {
+			entry.winners[i][j] = g.board[i][j].Winner
+		}
+	}
+	g.history = append(g.history, entry)
+
+	g.setCell(move, g.playing)
+	g.win = g.CheckWin()
+	g.round++
+	g.lastPlay = move
+	g.playing = g.playing.Opponent()
+}
+
+// Undo takes back the last move, it returns false when no move has been played
+func (g *Game) Undo() bool {
+	if len(g.history) == 0 {
+		return false
+	}
+	entry := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+
+	g.setCell(entry.move, EMPTY)
+	for i := 0; i < BoardRowLength; i++ {
+		for j := 0; j < BoardRowLength; j++ {
+			g.board[i][j].Winner = entry.winners[i][j]
+		}
+	}
+	g.win = entry.win
+	g.lastPlay = entry.lastPlay
+	g.round--
+	g.playing = g.playing.Opponent()
+	g.future = append(g.future, entry.move)
+	return true
+}
+
+// Redo plays again the last move taken back, it returns false when there is none
+func (g *Game) Redo() bool {
+	if len(g.future) == 0 {
+		return false
+	}
+	move := g.future[len(g.future)-1]
+	g.future = g.future[:len(g.future)-1]
+	g.play(move)
+	return true
+}
+
+// CanUndo tells if a move can be taken back
+func (g *Game) CanUndo() bool {
+	return len(g.history) > 0
+}
+
+// CanRedo tells if a move taken back can be played again
+func (g *Game) CanRedo() bool {
+	return len(g.future) > 0
+}
+
+// History returns the moves played since the start of the game
+func (g *Game) History() []Move {
+	moves := make([]Move, len(g.history))
+	for i, entry := range g.history {
+		moves[i] = entry.move
+	}
+	return moves
+}
+
+// CheckWin updates the winners of the mini-boards and returns the winner of the game,
+// NONE for a draw or EMPTY if the game has not ended yet
+func (g *Game) CheckWin() GameSymbol {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			g.board[i][j].CheckWin()
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if g.winnerOnLine(i, 0, 0, 1) != EMPTY {
+			return g.winnerOnLine(i, 0, 0, 1)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if g.winnerOnLine(0, i, 1, 0) != EMPTY {
+			return g.winnerOnLine(0, i, 1, 0)
+		}
+	}
+	if g.winnerOnLine(0, 0, 1, 1) != EMPTY {
+		return g.winnerOnLine(0, 0, 1, 1)
+	}
+	if g.winnerOnLine(0, 2, 1, -1) != EMPTY {
+		return g.winnerOnLine(0, 2, 1, -1)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if g.board[i][j].Winner == EMPTY {
+				return EMPTY
+			}
+		}
+	}
+
+	return NONE
+}
+
+// winnerOnLine checks if there is a winner on the given line,
+// NONE when its three mini-boards are drawn, which ends the game as a draw
+// x, y: the starting point of the line
+// dx, dy: delta applied to x and y to get the next point on the line
+func (g *Game) winnerOnLine(x, y, dx, dy int) GameSymbol {
+	for i := 0; i < 3; i++ {
+		if g.board[x][y].Winner != g.board[x+dx*i][y+dy*i].Winner {
+			return EMPTY
+		}
+	}
+	return g.board[x][y].Winner
+}
+
+// Clone returns a deep copy of the game
+func (g *Game) Clone() *Game {
+	clonedGame := *g
+	clonedGame.history = append([]historyEntry(nil), g.history...)
+	clonedGame.future = append([]Move(nil), g.future...)
+	return &clonedGame
+}
@@ -0,0 +1,245 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+// mustPlay plays a move that is expected to be legal
+func mustPlay(t *testing.T, game *Game, move Move) {
+	t.Helper()
+	if err := game.Play(move); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	game := NewGame(PLAYER2)
+
+	if game.Playing() != PLAYER2 {
+		t.Errorf("Unexpected player: %v", game.Playing())
+	}
+	if game.Round() != 0 {
+		t.Errorf("Unexpected round: %d", game.Round())
+	}
+	if game.Winner() != EMPTY {
+		t.Errorf("Unexpected win: %v", game.Winner())
+	}
+	if len(game.PossibleMoves()) != 81 {
+		t.Errorf("Expected 81 possible moves, got %d", len(game.PossibleMoves()))
+	}
+}
+
+func TestIsValidPlay(t *testing.T) {
+	game := NewGame(PLAYER1)
+
+	// Test when lastPlay is -1
+	if !game.IsValidPlay(0, 0) {
+		t.Errorf("Expected true, got false")
+	}
+
+	// Test when lastPlay is not -1
+	game.lastPlay = Move{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 0, MiniBoardCol: 0}
+	if !game.IsValidPlay(0, 0) {
+		t.Errorf("Expected true, got false")
+	}
+
+	if game.IsValidPlay(1, 1) {
+		t.Errorf("Expected false, got true")
+	}
+}
+
+func TestCell(t *testing.T) {
+	game := NewGame(PLAYER1)
+
+	coordinates := Move{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 0, MiniBoardCol: 0}
+	game.setCell(coordinates, PLAYER1)
+
+	if game.Cell(coordinates) != PLAYER1 {
+		t.Errorf("Expected %v, got %v", PLAYER1, game.Cell(coordinates))
+	}
+	if game.board[0][0].Board[0][0] != PLAYER1 {
+		t.Errorf("Expected %v, got %v", PLAYER1, game.board[0][0].Board[0][0])
+	}
+}
+
+func TestPlay(t *testing.T) {
+	game := NewGame(PLAYER1)
+	move := Move{MainBoardRow: 1, MainBoardCol: 2, MiniBoardRow: 0, MiniBoardCol: 1}
+	mustPlay(t, game, move)
+
+	if game.Cell(move) != PLAYER1 {
+		t.Errorf("Expected %v, got %v", PLAYER1, game.Cell(move))
+	}
+	if game.Playing() != PLAYER2 || game.Round() != 1 || game.LastMove() != move {
+		t.Errorf("Unexpected state after move: %v %d %v", game.Playing(), game.Round(), game.LastMove())
+	}
+	for _, m := range game.PossibleMoves() {
+		if m.MainBoardRow != 0 || m.MainBoardCol != 1 {
+			t.Errorf("Move %v is outside the forced mini-board", m)
+		}
+	}
+}
+
+func TestCheckWin(t *testing.T) {
+	game := NewGame(PLAYER1)
+
+	// Set up a winning condition for PLAYER1
+	for i := 0; i < 3; i++ {
+		game.board[0][i].Board[i][0] = PLAYER1
+		game.board[0][i].Board[i][1] = PLAYER1
+		game.board[0][i].Board[i][2] = PLAYER1
+	}
+
+	if game.CheckWin() != PLAYER1 {
+		t.Errorf("Expected %v, got %v", PLAYER1, game.CheckWin())
+	}
+}
+
+func TestCheckWinDrawnLine(t *testing.T) {
+	game := NewGame(PLAYER1)
+	drawn := [3][3]GameSymbol{
+		{PLAYER1, PLAYER2, PLAYER1},
+		{PLAYER1, PLAYER2, PLAYER2},
+		{PLAYER2, PLAYER1, PLAYER1}}
+	for i := 0; i < 3; i++ {
+		game.board[i][0].Board = drawn
+	}
+
+	// a line of drawn mini-boards ends the game as a draw
+	if game.CheckWin() != NONE {
+		t.Errorf("Expected %q, got %q", NONE, game.CheckWin())
+	}
+	if game.MiniBoardWinner(1, 0) != NONE {
+		t.Errorf("Expected drawn mini-board, got %q", game.MiniBoardWinner(1, 0))
+	}
+}
+
+func TestClone(t *testing.T) {
+	game := NewGame(PLAYER1)
+	clone := game.Clone()
+	mustPlay(t, clone, clone.PossibleMoves()[0])
+
+	if game.Round() != 0 || game.Cell(clone.LastMove()) != EMPTY {
+		t.Errorf("Playing on a clone changed the original game")
+	}
+}
+
+func TestSimulateGame(t *testing.T) {
+	game := NewGame(PLAYER1)
+	for !game.IsOver() {
+		mustPlay(t, game, game.PossibleMoves()[0])
+	}
+	if len(game.PossibleMoves()) != 0 {
+		t.Errorf("Expected no possible move once the game is over")
+	}
+}
+
+// samePosition tells if two games have the same board, winners, player to move and forced mini-board
+func samePosition(a, b *Game) bool {
+	return a.board == b.board && a.playing == b.playing && a.round == b.round &&
+		a.win == b.win && a.lastPlay == b.lastPlay
+}
+
+func TestUndoRestoresPosition(t *testing.T) {
+	game := NewGame(PLAYER1)
+	positions := []*Game{game.Clone()}
+	for !game.IsOver() {
+		mustPlay(t, game, game.PossibleMoves()[0])
+		positions = append(positions, game.Clone())
+	}
+
+	for i := len(positions) - 2; i >= 0; i-- {
+		if !game.Undo() {
+			t.Fatalf("Expected a move to undo at round %d", game.Round())
+		}
+		if !samePosition(game, positions[i]) {
+			t.Fatalf("Undo did not restore the position of round %d", i)
+		}
+	}
+	if game.Undo() {
+		t.Errorf("Expected no move to undo at the start of the game")
+	}
+
+	for i := 1; i < len(positions); i++ {
+		if !game.Redo() {
+			t.Fatalf("Expected a move to redo at round %d", game.Round())
+		}
+		if !samePosition(game, positions[i]) {
+			t.Fatalf("Redo did not restore the position of round %d", i)
+		}
+	}
+	if game.Redo() {
+		t.Errorf("Expected no move to redo at the end of the game")
+	}
+}
+
+func TestPlayClearsRedo(t *testing.T) {
+	game := NewGame(PLAYER1)
+	mustPlay(t, game, game.PossibleMoves()[0])
+	mustPlay(t, game, game.PossibleMoves()[0])
+	game.Undo()
+
+	if !game.CanRedo() {
+		t.Fatalf("Expected a move to redo")
+	}
+	mustPlay(t, game, game.PossibleMoves()[1])
+	if game.CanRedo() {
+		t.Errorf("Expected no move to redo after a new move")
+	}
+	if len(game.History()) != 2 || game.History()[1] != game.LastMove() {
+		t.Errorf("Unexpected history: %v", game.History())
+	}
+}
+
+func TestPlayErrors(t *testing.T) {
+	game := NewGame(PLAYER1)
+	mustPlay(t, game, Move{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 0, MiniBoardCol: 0})
+
+	tests := []struct {
+		move Move
+		err  error
+	}{
+		{Move{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 0, MiniBoardCol: 0}, ErrOccupied},
+		{Move{MainBoardRow: 1, MainBoardCol: 1, MiniBoardRow: 0, MiniBoardCol: 0}, ErrWrongBoard},
+		{Move{MainBoardRow: 0, MainBoardCol: 3, MiniBoardRow: 0, MiniBoardCol: 0}, ErrOutOfRange},
+		{Move{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: -1, MiniBoardCol: 0}, ErrOutOfRange},
+	}
+	for _, test := range tests {
+		err := game.Play(test.move)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Move %v: expected %v, got %v", test.move, test.err, err)
+		}
+		var moveError *MoveError
+		if !errors.As(err, &moveError) || moveError.Move != test.move {
+			t.Errorf("Move %v: expected a MoveError, got %v", test.move, err)
+		}
+	}
+	if game.Round() != 1 {
+		t.Errorf("Refused moves changed the game, round %d", game.Round())
+	}
+}
+
+func TestPlayDecidedBoard(t *testing.T) {
+	game := NewGame(PLAYER1)
+	game.board[1][1].Winner = PLAYER2
+	game.lastPlay = Move{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 1, MiniBoardCol: 1}
+
+	err := game.Play(Move{MainBoardRow: 1, MainBoardCol: 1, MiniBoardRow: 0, MiniBoardCol: 0})
+	if !errors.Is(err, ErrBoardDecided) {
+		t.Errorf("Expected %v, got %v", ErrBoardDecided, err)
+	}
+	mustPlay(t, game, Move{MainBoardRow: 2, MainBoardCol: 2, MiniBoardRow: 0, MiniBoardCol: 0})
+}
+
+func TestPlayGameOver(t *testing.T) {
+	game := NewGame(PLAYER1)
+	for !game.IsOver() {
+		mustPlay(t, game, game.PossibleMoves()[0])
+	}
+	for i := 0; i < 81; i++ {
+		if err := game.Play(BitMove(i).Move()); !errors.Is(err, ErrGameOver) {
+			t.Fatalf("Expected %v, got %v", ErrGameOver, err)
+		}
+	}
+}
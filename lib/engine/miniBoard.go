@@ -1,10 +1,12 @@
-package main
+package engine
 
+// MiniBoard is one of the nine small tic-tac-toe boards composing the game
 type MiniBoard struct {
 	Board  [3][3]GameSymbol
 	Winner GameSymbol
 }
 
+// CheckWin updates the winner of the mini-board, NONE when it is full without winner
 func (g *MiniBoard) CheckWin() {
 	for i := 0; i < 3; i++ {
 		if g.winnerOnLine(i, 0, 0, 1) != EMPTY {
@@ -43,3 +45,13 @@ func (g *MiniBoard) winnerOnLine(x, y, dx, dy int) GameSymbol {
 	}
 	return g.Board[x][y]
 }
+
+// hasLine tells if the player has three symbols in a row in the mini-board
+func (g *MiniBoard) hasLine(player GameSymbol) bool {
+	for i := 0; i < 3; i++ {
+		if g.winnerOnLine(i, 0, 0, 1) == player || g.winnerOnLine(0, i, 1, 0) == player {
+			return true
+		}
+	}
+	return g.winnerOnLine(0, 0, 1, 1) == player || g.winnerOnLine(0, 2, 1, -1) == player
+}
@@ -0,0 +1,12 @@
+package engine
+
+// Move identifies a cell of the game: the mini-board it belongs to and the cell inside that mini-board
+type Move struct {
+	MainBoardRow int
+	MainBoardCol int
+	MiniBoardRow int
+	MiniBoardCol int
+}
+
+// NoMove is the move used before the first play of a game
+var NoMove = Move{MainBoardRow: -1, MainBoardCol: -1, MiniBoardRow: -1, MiniBoardCol: -1}
@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidNotation is returned by ParseMove, wrapped with the text that could not be read
+var ErrInvalidNotation = errors.New("invalid move notation")
+
+// String returns the move in the notation of the game: the letter of the mini-board followed by the digit of the cell.
+// Mini-boards are lettered from a to i and cells numbered from 1 to 9, both row by row from the top left,
+// so "e5" is the center of the center mini-board and "a9" the bottom right cell of the top left mini-board.
+// NoMove is written "-".
+func (m Move) String() string {
+	for _, coordinate := range []int{m.MainBoardRow, m.MainBoardCol, m.MiniBoardRow, m.MiniBoardCol} {
+		if coordinate < 0 || coordinate >= BoardRowLength {
+			return "-"
+		}
+	}
+	board := m.MainBoardRow*BoardRowLength + m.MainBoardCol
+	cell := m.MiniBoardRow*BoardRowLength + m.MiniBoardCol
+	return fmt.Sprintf("%c%c", 'a'+board, '1'+cell)
+}
+
+// ParseMove reads a move written by Move.String, the letter of the mini-board can be upper case
+func ParseMove(s string) (Move, error) {
+	notation := strings.ToLower(strings.TrimSpace(s))
+	if len(notation) != 2 || notation[0] < 'a' || notation[0] > 'i' || notation[1] < '1' || notation[1] > '9' {
+		return NoMove, fmt.Errorf("%w: %q", ErrInvalidNotation, s)
+	}
+	board := int(notation[0] - 'a')
+	cell := int(notation[1] - '1')
+	return Move{
+		MainBoardRow: board / BoardRowLength,
+		MainBoardCol: board % BoardRowLength,
+		MiniBoardRow: cell / BoardRowLength,
+		MiniBoardCol: cell % BoardRowLength,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMoveString(t *testing.T) {
+	tests := []struct {
+		move     Move
+		notation string
+	}{
+		{Move{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 0, MiniBoardCol: 0}, "a1"},
+		{Move{MainBoardRow: 1, MainBoardCol: 1, MiniBoardRow: 1, MiniBoardCol: 1}, "e5"},
+		{Move{MainBoardRow: 0, MainBoardCol: 2, MiniBoardRow: 2, MiniBoardCol: 0}, "c7"},
+		{Move{MainBoardRow: 2, MainBoardCol: 2, MiniBoardRow: 2, MiniBoardCol: 2}, "i9"},
+		{NoMove, "-"},
+	}
+	for _, test := range tests {
+		if test.move.String() != test.notation {
+			t.Errorf("Expected %q, got %q", test.notation, test.move.String())
+		}
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	for i := 0; i < 81; i++ {
+		move := BitMove(i).Move()
+		parsed, err := ParseMove(move.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != move {
+			t.Errorf("Expected %v, got %v", move, parsed)
+		}
+	}
+	if move, err := ParseMove("E5"); err != nil || move.String() != "e5" {
+		t.Errorf("Expected e5, got %v %v", move, err)
+	}
+	for _, notation := range []string{"", "e", "e0", "j1", "55", "e55"} {
+		if _, err := ParseMove(notation); !errors.Is(err, ErrInvalidNotation) {
+			t.Errorf("Expected %v for %q, got %v", ErrInvalidNotation, notation, err)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPosition is returned by ParsePosition, wrapped with the reason of the refusal
+var ErrInvalidPosition = errors.New("invalid position")
+
+// characters of the position string
+const (
+	positionEmpty = '.'
+	positionDraw  = '='
+	positionAny   = "-"
+)
+
+// ForcedBoard returns the mini-board that must receive the next move,
+// ok is false when any open mini-board can or when the game is over
+func (g *Game) ForcedBoard() (row, col int, ok bool) {
+	if g.win != EMPTY || g.lastPlay.MiniBoardRow == -1 || g.board[g.lastPlay.MiniBoardRow][g.lastPlay.MiniBoardCol].Winner != EMPTY {
+		return 0, 0, false
+	}
+	return g.lastPlay.MiniBoardRow, g.lastPlay.MiniBoardCol, true
+}
+
+// String returns the position of the game as four fields separated by spaces:
+//   - the cells of the nine mini-boards separated by '/', as O, X or '.' for an empty cell,
+//     mini-boards and cells being listed row by row
+//   - the player to move, O or X
+//   - the forced mini-board numbered from 1 to 9, or '-' when the move can be played in any open mini-board
+//   - the winners of the nine mini-boards, as O, X, '=' for a draw or '.' for a mini-board still open
+//
+// The starting position where O plays first is
+// "........./........./........./........./........./........./........./........./......... O - .........".
+func (g *Game) String() string {
+	var sb strings.Builder
+	for board := 0; board < 9; board++ {
+		if board > 0 {
+			sb.WriteByte('/')
+		}
+		miniBoard := g.board[board/BoardRowLength][board%BoardRowLength]
+		for cell := 0; cell < 9; cell++ {
+			sb.WriteByte(symbolToPosition(miniBoard.Board[cell/BoardRowLength][cell%BoardRowLength]))
+		}
+	}
+	sb.WriteByte(' ')
+	sb.WriteByte(symbolToPosition(g.playing))
+	sb.WriteByte(' ')
+	if row, col, ok := g.ForcedBoard(); ok {
+		sb.WriteByte(byte('1' + row*BoardRowLength + col))
+	} else {
+		sb.WriteString(positionAny)
+	}
+	sb.WriteByte(' ')
+	for board := 0; board < 9; board++ {
+		sb.WriteByte(symbolToPosition(g.board[board/BoardRowLength][board%BoardRowLength].Winner))
+	}
+	return sb.String()
+}
+
+// ParsePosition reads a position written by Game.String.
+// The position is checked for consistency: piece counts matching the player to move,
+// mini-board winners matching their cells, no winner to move in a finished game
+// and a forced mini-board reachable by the last move of a game still going on.
+// The history of the returned game is empty.
+func ParsePosition(s string) (*Game, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("%w: expected 4 fields, got %d", ErrInvalidPosition, len(fields))
+	}
+	g := NewGame(PLAYER1)
+
+	// cells
+	boards := strings.Split(fields[0], "/")
+	if len(boards) != 9 {
+		return nil, fmt.Errorf("%w: expected 9 mini-boards, got %d", ErrInvalidPosition, len(boards))
+	}
+	pieces := map[GameSymbol]int{}
+	for board, cells := range boards {
+		if len(cells) != 9 {
+			return nil, fmt.Errorf("%w: expected 9 cells in mini-board %d, got %d", ErrInvalidPosition, board+1, len(cells))
+		}
+		for cell := 0; cell < 9; cell++ {
+			symbol, ok := positionToSymbol(cells[cell])
+			if !ok || symbol == NONE {
+				return nil, fmt.Errorf("%w: unexpected cell %q in mini-board %d", ErrInvalidPosition, cells[cell], board+1)
+			}
+			g.board[board/BoardRowLength][board%BoardRowLength].Board[cell/BoardRowLength][cell%BoardRowLength] = symbol
+			pieces[symbol]++
+		}
+	}
+
+	// player to move
+	if len(fields[1]) != 1 {
+		return nil, fmt.Errorf("%w: unexpected player %q", ErrInvalidPosition, fields[1])
+	}
+	playing, ok := positionToSymbol(fields[1][0])
+	if !ok || (playing != PLAYER1 && playing != PLAYER2) {
+		return nil, fmt.Errorf("%w: unexpected player %q", ErrInvalidPosition, fields[1])
+	}
+	difference := pieces[playing] - pieces[playing.Opponent()]
+	if difference != 0 && difference != -1 {
+		return nil, fmt.Errorf("%w: %d pieces of O and %d of X with %c to move",
+			ErrInvalidPosition, pieces[PLAYER1], pieces[PLAYER2], playing)
+	}
+	g.playing = playing
+	g.round = pieces[PLAYER1] + pieces[PLAYER2]
+
+	// mini-board winners
+	if len(fields[3]) != 9 {
+		return nil, fmt.Errorf("%w: expected 9 mini-board winners, got %d", ErrInvalidPosition, len(fields[3]))
+	}
+	for board := 0; board < 9; board++ {
+		winner, ok := positionToSymbol(fields[3][board])
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected winner %q of mini-board %d", ErrInvalidPosition, fields[3][board], board+1)
+		}
+		miniBoard := &g.board[board/BoardRowLength][board%BoardRowLength]
+		if miniBoard.hasLine(PLAYER1) && miniBoard.hasLine(PLAYER2) {
+			return nil, fmt.Errorf("%w: both players won mini-board %d", ErrInvalidPosition, board+1)
+		}
+		miniBoard.CheckWin()
+		if miniBoard.Winner != winner {
+			return nil, fmt.Errorf("%w: mini-board %d is given to %q but its cells give %q",
+				ErrInvalidPosition, board+1, winner, miniBoard.Winner)
+		}
+	}
+	g.win = g.CheckWin()
+	// the winner played the last move, the turn went to the opponent
+	if g.win == playing {
+		return nil, fmt.Errorf("%w: %c to move after winning the game", ErrInvalidPosition, playing)
+	}
+
+	// forced mini-board, the last move is searched among the cells of the previous player
+	forced := -1
+	if fields[2] != positionAny {
+		if len(fields[2]) != 1 || fields[2][0] < '1' || fields[2][0] > '9' {
+			return nil, fmt.Errorf("%w: unexpected forced mini-board %q", ErrInvalidPosition, fields[2])
+		}
+		forced = int(fields[2][0] - '1')
+		if g.board[forced/BoardRowLength][forced%BoardRowLength].Winner != EMPTY {
+			return nil, fmt.Errorf("%w: forced mini-board %d is already decided", ErrInvalidPosition, forced+1)
+		}
+		if g.round == 0 {
+			return nil, fmt.Errorf("%w: forced mini-board %d before the first move", ErrInvalidPosition, forced+1)
+		}
+		if g.win != EMPTY {
+			return nil, fmt.Errorf("%w: forced mini-board %d in a finished game", ErrInvalidPosition, forced+1)
+		}
+	}
+	if g.round > 0 {
+		lastPlay, ok := g.findLastPlay(forced)
+		if !ok && forced >= 0 {
+			return nil, fmt.Errorf("%w: no move of %c can force mini-board %d", ErrInvalidPosition, playing.Opponent(), forced+1)
+		} else if !ok && g.win == EMPTY {
+			return nil, fmt.Errorf("%w: no move of %c can send to a decided mini-board", ErrInvalidPosition, playing.Opponent())
+		}
+		g.lastPlay = lastPlay
+	}
+	return g, nil
+}
+
+// findLastPlay returns a cell of the previous player that sends to the forced mini-board,
+// or to a decided mini-board when forced is -1
+func (g *Game) findLastPlay(forced int) (Move, bool) {
+	for board := 0; board < 9; board++ {
+		for cell := 0; cell < 9; cell++ {
+			move := Move{
+				MainBoardRow: board / BoardRowLength,
+				MainBoardCol: board % BoardRowLength,
+				MiniBoardRow: cell / BoardRowLength,
+				MiniBoardCol: cell % BoardRowLength,
+			}
+			if g.Cell(move) != g.playing.Opponent() {
+				continue
+			}
+			decided := g.board[move.MiniBoardRow][move.MiniBoardCol].Winner != EMPTY
+			if (forced == -1 && decided) || cell == forced {
+				return move, true
+			}
+		}
+	}
+	return NoMove, false
+}
+
+func symbolToPosition(symbol GameSymbol) byte {
+	switch symbol {
+	case PLAYER1, PLAYER2:
+		return byte(symbol)
+	case NONE:
+		return positionDraw
+	}
+	return positionEmpty
+}
+
+func positionToSymbol(c byte) (GameSymbol, bool) {
+	switch c {
+	case byte(PLAYER1), byte(PLAYER2):
+		return GameSymbol(c), true
+	case positionDraw:
+		return NONE, true
+	case positionEmpty:
+		return EMPTY, true
+	}
+	return EMPTY, false
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+const startPosition = "........./........./........./........./........./........./........./........./......... O - ........."
+
+func TestStartPosition(t *testing.T) {
+	game := NewGame(PLAYER1)
+	if game.String() != startPosition {
+		t.Errorf("Expected %q, got %q", startPosition, game.String())
+	}
+	parsed, err := ParsePosition(startPosition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !samePosition(game, parsed) {
+		t.Errorf("Parsed start position differs from a new game")
+	}
+}
+
+func TestPositionRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		game := NewGame(PLAYER2)
+		for {
+			parsed, err := ParsePosition(game.String())
+			if err != nil {
+				t.Fatalf("Parsing %q: %v", game.String(), err)
+			}
+			if parsed.String() != game.String() {
+				t.Fatalf("Expected %q, got %q", game.String(), parsed.String())
+			}
+			if len(parsed.PossibleMoves()) != len(game.PossibleMoves()) || parsed.Winner() != game.Winner() {
+				t.Fatalf("Parsed position %q does not play like the game", game.String())
+			}
+			if game.IsOver() {
+				break
+			}
+			moves := game.PossibleMoves()
+			mustPlay(t, game, moves[rng.Intn(len(moves))])
+		}
+	}
+}
+
+func TestPositionForcedBoard(t *testing.T) {
+	game := NewGame(PLAYER1)
+	mustPlay(t, game, Move{MainBoardRow: 0, MainBoardCol: 0, MiniBoardRow: 1, MiniBoardCol: 2})
+
+	expected := ".....O.../........./........./........./........./........./........./........./......... X 6 ........."
+	if game.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, game.String())
+	}
+}
+
+func TestParseFinishedPosition(t *testing.T) {
+	position := "OOO....../OOO....../OOO....../XX......./XX......./XX......./XX......./........./......... X - OOO......"
+	game, err := ParsePosition(position)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Winner() != PLAYER1 || game.String() != position {
+		t.Errorf("Expected %q won by O, got %q won by %q", position, game.String(), game.Winner())
+	}
+}
+
+func TestParsePositionErrors(t *testing.T) {
+	positions := []string{
+		"",
+		"........./........./........./........./........./........./........./......... O - .........",
+		"........./........./........./........./........./........./........./........./........A O - .........",
+		"........./........./........./........./........./........./........./........./......... Z - .........",
+		// too many pieces of O
+		"OO......./........./........./........./........./........./........./........./......... O - .........",
+		// winner not matching the cells
+		"........./........./........./........./........./........./........./........./......... O - O........",
+		"OOO....XX/X......../........./........./........./........./........./........./......... X - .........",
+		// forced mini-board not reachable by the last move
+		"O......../........./........./........./........./........./........./........./......... X 5 .........",
+		// forced mini-board already decided
+		"OOO....XX/X.O....../........./........./........./........./........./........./......... X 1 O........",
+		// free move without a move sending to a decided mini-board
+		"O......../........./........./........./........./........./........./........./......... X - .........",
+		// forced mini-board before the first move
+		"........./........./........./........./........./........./........./........./......... O 5 .........",
+		// forced mini-board in a finished game
+		"OOO....../OOO....../OOO....../XX......./XX......./XX......./XX......./........./......... X 9 OOO......",
+		// winner to move in a finished game
+		"OOO....../OOO....../OOO....../XX......./XX......./XX......./XX......./X......../......... O - OOO......",
+	}
+	for _, position := range positions {
+		if _, err := ParsePosition(position); !errors.Is(err, ErrInvalidPosition) {
+			t.Errorf("Expected %v for %q, got %v", ErrInvalidPosition, position, err)
+		}
+	}
+}
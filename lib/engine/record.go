@@ -0,0 +1,249 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRecord is returned by ParseRecord, wrapped with the reason of the refusal
+var ErrInvalidRecord = errors.New("invalid game record")
+
+// RecordDateFormat is the layout of the Date header of a record
+const RecordDateFormat = "2006.01.02"
+
+// headers written by Record.String, in this order, before the extra headers
+const (
+	headerPlayerO      = "O"
+	headerPlayerX      = "X"
+	headerDate         = "Date"
+	headerResult       = "Result"
+	headerAIDifficulty = "AIDifficulty"
+	headerFirst        = "First"
+	headerPosition     = "Position"
+)
+
+// results of the Result header
+const (
+	resultDraw       = "draw"
+	resultUnfinished = "*"
+)
+
+// Record is a whole game with its headers. It is written as one header per line, an empty line and the moves:
+//
+//	[O "Alice"]
+//	[X "AI"]
+//	[Date "2024.06.01"]
+//	[Result "O"]
+//	[AIDifficulty "2"]
+//	[First "O"]
+//
+//	1. e5 e1 2. a5 e2 ...
+//
+// Result is O, X, draw or * for an unfinished game. Move numbers are optional when reading.
+// A game which did not start from the empty board has a Position header after First,
+// the position string of its start, where First is the player to move.
+type Record struct {
+	PlayerO      string            // name of the player of O
+	PlayerX      string            // name of the player of X
+	Date         string            // day of the game, in RecordDateFormat
+	Result       GameSymbol        // winner, NONE for a draw or EMPTY for an unfinished game
+	AIDifficulty string            // difficulty of the AI, empty for a game between humans
+	First        GameSymbol        // player of the first move
+	Position     string            // position of the start in the format of Game.String, empty for the empty board
+	Moves        []Move            // moves played, in order
+	Extra        map[string]string // other headers
+}
+
+// NewRecord returns the record of the moves played in the game, dated today.
+// The record starts from the position before the moves of the history of the game,
+// like a game created by ParsePosition.
+func NewRecord(g *Game) *Record {
+	start := g.Clone()
+	for start.Undo() {
+	}
+	r := &Record{
+		Date:   time.Now().Format(RecordDateFormat),
+		Result: g.Winner(),
+		First:  start.Playing(),
+		Moves:  g.History(),
+	}
+	if position := start.String(); position != NewGame(start.Playing()).String() {
+		r.Position = position
+	}
+	return r
+}
+
+// Game replays the moves of the record from its start. Every move is kept in the history of the returned game,
+// so the game can be stepped through with Undo and Redo.
+func (r *Record) Game() (*Game, error) {
+	g := NewGame(r.First)
+	if r.Position != "" {
+		start, err := ParsePosition(r.Position)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+		if start.Playing() != r.First {
+			return nil, fmt.Errorf("%w: first player %s is not to move in the position", ErrInvalidRecord, string(r.First))
+		}
+		g = start
+	}
+	for i, move := range r.Moves {
+		if err := g.Play(move); err != nil {
+			return nil, fmt.Errorf("%w: move %d: %w", ErrInvalidRecord, i+1, err)
+		}
+	}
+	return g, nil
+}
+
+// String returns the record in the format described on Record
+func (r *Record) String() string {
+	var sb strings.Builder
+	writeHeader := func(name, value string) {
+		fmt.Fprintf(&sb, "[%s %s]\n", name, strconv.Quote(value))
+	}
+	writeHeader(headerPlayerO, r.PlayerO)
+	writeHeader(headerPlayerX, r.PlayerX)
+	writeHeader(headerDate, r.Date)
+	writeHeader(headerResult, resultToString(r.Result))
+	if r.AIDifficulty != "" {
+		writeHeader(headerAIDifficulty, r.AIDifficulty)
+	}
+	writeHeader(headerFirst, string(r.First))
+	if r.Position != "" {
+		writeHeader(headerPosition, r.Position)
+	}
+	extra := make([]string, 0, len(r.Extra))
+	for name := range r.Extra {
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		writeHeader(name, r.Extra[name])
+	}
+
+	sb.WriteByte('\n')
+	for i, move := range r.Moves {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		if i%2 == 0 {
+			fmt.Fprintf(&sb, "%d. ", i/2+1)
+		}
+		sb.WriteString(move.String())
+	}
+	sb.WriteByte('\n')
+	return sb.String()
+}
+
+// ParseRecord reads a record written by Record.String.
+// The moves are replayed, the record is refused if one of them is illegal or if the result does not match the game.
+func ParseRecord(s string) (*Record, error) {
+	r := &Record{Result: EMPTY, First: PLAYER1}
+	lines := strings.Split(s, "\n")
+	i := 0
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "[") {
+			break
+		}
+		if err := r.parseHeader(line); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, token := range strings.Fields(strings.Join(lines[i:], " ")) {
+		if strings.HasSuffix(token, ".") {
+			// move number
+			continue
+		}
+		move, err := ParseMove(token)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+		r.Moves = append(r.Moves, move)
+	}
+
+	g, err := r.Game()
+	if err != nil {
+		return nil, err
+	}
+	if g.Winner() != r.Result {
+		return nil, fmt.Errorf("%w: result %s does not match the moves, giving %s",
+			ErrInvalidRecord, resultToString(r.Result), resultToString(g.Winner()))
+	}
+	return r, nil
+}
+
+// parseHeader reads a line [Name "value"]
+func (r *Record) parseHeader(line string) error {
+	if !strings.HasSuffix(line, "]") {
+		return fmt.Errorf("%w: unterminated header %q", ErrInvalidRecord, line)
+	}
+	name, quoted, found := strings.Cut(line[1:len(line)-1], " ")
+	if !found {
+		return fmt.Errorf("%w: header %q without value", ErrInvalidRecord, line)
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(quoted))
+	if err != nil {
+		return fmt.Errorf("%w: header %q: %w", ErrInvalidRecord, line, err)
+	}
+
+	switch name {
+	case headerPlayerO:
+		r.PlayerO = value
+	case headerPlayerX:
+		r.PlayerX = value
+	case headerDate:
+		r.Date = value
+	case headerAIDifficulty:
+		r.AIDifficulty = value
+	case headerResult:
+		result, ok := stringToResult(value)
+		if !ok {
+			return fmt.Errorf("%w: unexpected result %q", ErrInvalidRecord, value)
+		}
+		r.Result = result
+	case headerFirst:
+		if value != string(PLAYER1) && value != string(PLAYER2) {
+			return fmt.Errorf("%w: unexpected first player %q", ErrInvalidRecord, value)
+		}
+		r.First = GameSymbol(value[0])
+	case headerPosition:
+		r.Position = value
+	default:
+		if r.Extra == nil {
+			r.Extra = map[string]string{}
+		}
+		r.Extra[name] = value
+	}
+	return nil
+}
+
+func resultToString(result GameSymbol) string {
+	switch result {
+	case PLAYER1, PLAYER2:
+		return string(result)
+	case NONE:
+		return resultDraw
+	}
+	return resultUnfinished
+}
+
+func stringToResult(s string) (GameSymbol, bool) {
+	switch s {
+	case string(PLAYER1), string(PLAYER2):
+		return GameSymbol(s[0]), true
+	case resultDraw:
+		return NONE, true
+	case resultUnfinished:
+		return EMPTY, true
+	}
+	return EMPTY, false
+}
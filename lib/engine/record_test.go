@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecordRoundTrip(t *testing.T) {
+	game := NewGame(PLAYER2)
+	for !game.IsOver() {
+		mustPlay(t, game, game.PossibleMoves()[0])
+	}
+	record := NewRecord(game)
+	record.PlayerO = "Alice"
+	record.PlayerX = "AI \"hard\""
+	record.AIDifficulty = "2"
+	record.Extra = map[string]string{"Event": "Tournament"}
+
+	parsed, err := ParseRecord(record.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.String() != record.String() {
+		t.Errorf("Expected\n%s\ngot\n%s", record, parsed)
+	}
+	if parsed.First != PLAYER2 || parsed.Result != game.Winner() || len(parsed.Moves) != game.Round() {
+		t.Errorf("Unexpected record: %+v", parsed)
+	}
+
+	replayed, err := parsed.Game()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !samePosition(replayed, game) {
+		t.Errorf("Replayed game differs from the recorded game")
+	}
+}
+
+func TestRecordFromPosition(t *testing.T) {
+	played := NewGame(PLAYER1)
+	for i := 0; i < 7; i++ {
+		mustPlay(t, played, played.PossibleMoves()[0])
+	}
+	game, err := ParsePosition(played.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		mustPlay(t, game, game.PossibleMoves()[0])
+	}
+	record := NewRecord(game)
+	if record.Position != played.String() || record.First != played.Playing() || len(record.Moves) != 3 {
+		t.Fatalf("Expected the record to start from the parsed position, got %+v", record)
+	}
+
+	parsed, err := ParseRecord(record.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.String() != record.String() {
+		t.Errorf("Expected\n%s\ngot\n%s", record, parsed)
+	}
+	replayed, err := parsed.Game()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !samePosition(replayed, game) {
+		t.Errorf("Replayed game differs from the recorded game")
+	}
+
+	parsed.First = parsed.First.Opponent()
+	if _, err := parsed.Game(); !errors.Is(err, ErrInvalidRecord) {
+		t.Errorf("Expected the first player to be the player to move in the position, got %v", err)
+	}
+	if record := NewRecord(NewGame(PLAYER2)); record.Position != "" || record.First != PLAYER2 {
+		t.Errorf("Expected no position for the empty board, got %+v", record)
+	}
+}
+
+func TestParseRecord(t *testing.T) {
+	record, err := ParseRecord(`[O "Alice"]
+[X "Bob"]
+[Result "*"]
+
+e5 e1
+a5`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.PlayerO != "Alice" || record.PlayerX != "Bob" || record.First != PLAYER1 {
+		t.Errorf("Unexpected headers: %+v", record)
+	}
+	if len(record.Moves) != 3 || record.Moves[2].String() != "a5" {
+		t.Errorf("Unexpected moves: %v", record.Moves)
+	}
+}
+
+func TestParseRecordErrors(t *testing.T) {
+	records := []string{
+		"[O \"Alice\"\n\ne5",
+		"[O Alice]\n\ne5",
+		"[Result \"Y\"]\n\ne5",
+		"[First \"Y\"]\n\ne5",
+		"[Position \"nowhere\"]\n\ne5",
+		"e5 z1",
+		// e2 is not in the forced mini-board
+		"e5 e1 e2",
+		// unfinished game given as won
+		"[Result \"O\"]\n\ne5 e1",
+	}
+	for _, record := range records {
+		if _, err := ParseRecord(record); !errors.Is(err, ErrInvalidRecord) {
+			t.Errorf("Expected %v for %q, got %v", ErrInvalidRecord, strings.ReplaceAll(record, "\n", " "), err)
+		}
+	}
+}
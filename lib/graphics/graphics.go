@@ -1,6 +1,7 @@
 package graphics
 
 import (
+	"GoTicTacToe/lib/engine"
 	"github.com/fogleman/gg"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -24,13 +25,7 @@ type GameGraphics struct {
 	MiniBoard *ebiten.Image
 	Circle    *ebiten.Image
 	Cross     *ebiten.Image
-}
-
-type BoardCoord struct {
-	MainBoardRow int
-	MainBoardCol int
-	MiniBoardRow int
-	MiniBoardCol int
+	Highlight *ebiten.Image // white cell, tinted and scaled to highlight cells and mini-boards
 }
 
 func Init(boardWidth int) GameGraphics {
@@ -42,6 +37,7 @@ func Init(boardWidth int) GameGraphics {
 	gameGraphics.Cross = drawCross()
 	gameGraphics.MainBoard = DrawMainBoard()
 	gameGraphics.MiniBoard = DrawMiniBoard()
+	gameGraphics.Highlight = drawHighlight()
 	return gameGraphics
 }
 
@@ -93,10 +89,19 @@ func drawCross() *ebiten.Image {
 	return ggm.getImage()
 }
 
-func GetPositionOfSymbol(boardCoord BoardCoord) (float64, float64) {
-	x := symbolSize*boardCoord.MiniBoardRow + miniBoardPadding
-	y := symbolSize*boardCoord.MiniBoardCol + miniBoardPadding
-	x += boardCoord.MainBoardRow * (miniBoardSize + mainBoardLineWidth + miniBoardPadding)
-	y += boardCoord.MainBoardCol * (miniBoardSize + mainBoardLineWidth + miniBoardPadding)
+func drawHighlight() *ebiten.Image {
+	ggm := gameGraphicMaker{gg.NewContext(symbolSize, symbolSize)}
+	ggm.setRGBA(255, 255, 255, 255)
+	ggm.drawRectangle(0, 0, symbolSize, symbolSize)
+	ggm.fill()
+	return ggm.getImage()
+}
+
+// GetPositionOfSymbol returns the top left corner of the cell, rows going down and columns going right
+func GetPositionOfSymbol(boardCoord engine.Move) (float64, float64) {
+	x := symbolSize*boardCoord.MiniBoardCol + miniBoardPadding
+	y := symbolSize*boardCoord.MiniBoardRow + miniBoardPadding
+	x += boardCoord.MainBoardCol * (miniBoardSize + mainBoardLineWidth + miniBoardPadding)
+	y += boardCoord.MainBoardRow * (miniBoardSize + mainBoardLineWidth + miniBoardPadding)
 	return float64(x), float64(y)
 }
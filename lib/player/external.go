@@ -0,0 +1,90 @@
+package player
+
+import (
+	"GoTicTacToe/lib/engine"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// External is a program playing through its standard input and output.
+// For each move, it receives a line "position <position>" with the position written by engine.Game.String
+// and answers a line with its move in the notation of engine.Move.String.
+type External struct {
+	name   string
+	cmd    *exec.Cmd
+	input  io.WriteCloser
+	output *bufio.Reader
+	closed bool
+}
+
+// NewExternal starts the program
+func NewExternal(path string, args ...string) (*External, error) {
+	cmd := exec.Command(path, args...)
+	input, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	output, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &External{
+		name:   filepath.Base(path),
+		cmd:    cmd,
+		input:  input,
+		output: bufio.NewReader(output),
+	}, nil
+}
+
+func (e *External) Name() string {
+	return e.name
+}
+
+// Move asks the program for its move. When the context is cancelled first the program is stopped,
+// since its late answer would be read as the next move.
+func (e *External) Move(ctx context.Context, game *engine.Game) (engine.Move, error) {
+	if _, err := fmt.Fprintf(e.input, "position %v\n", game); err != nil {
+		return engine.NoMove, err
+	}
+
+	type answer struct {
+		line string
+		err  error
+	}
+	answers := make(chan answer, 1)
+	go func() {
+		line, err := e.output.ReadString('\n')
+		answers <- answer{line, err}
+	}()
+
+	select {
+	case a := <-answers:
+		if a.err != nil {
+			return engine.NoMove, fmt.Errorf("reading the move of %s: %w", e.name, a.err)
+		}
+		return engine.ParseMove(strings.TrimSpace(a.line))
+	case <-ctx.Done():
+		e.Close()
+		return engine.NoMove, ctx.Err()
+	}
+}
+
+// Close stops the program
+func (e *External) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	e.input.Close()
+	e.cmd.Process.Kill()
+	e.cmd.Wait()
+	return nil
+}
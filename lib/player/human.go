@@ -0,0 +1,38 @@
+package player
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+)
+
+// Human is a player whose moves are given by a user interface with Submit
+type Human struct {
+	name  string
+	moves chan engine.Move
+}
+
+func NewHuman(name string) *Human {
+	return &Human{name: name, moves: make(chan engine.Move, 1)}
+}
+
+func (h *Human) Name() string {
+	return h.name
+}
+
+// Submit gives the move chosen by the human, it is dropped if a previous move has not been taken yet
+func (h *Human) Submit(move engine.Move) {
+	select {
+	case h.moves <- move:
+	default:
+	}
+}
+
+// Move waits for the next submitted move
+func (h *Human) Move(ctx context.Context, _ *engine.Game) (engine.Move, error) {
+	select {
+	case move := <-h.moves:
+		return move, nil
+	case <-ctx.Done():
+		return engine.NoMove, ctx.Err()
+	}
+}
@@ -0,0 +1,78 @@
+package player
+
+import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// MCTS plays the move found by the Monte Carlo tree search of the ai package,
+// keeping the tree of its previous move when the game goes on
+type MCTS struct {
+	config   ai.Config // budget and seed of the search
+	searchMu sync.Mutex
+	searcher *ai.Searcher // used by one move or pondering at a time
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// NewMCTS returns a player thinking for the duration on each move, on all the processors
+func NewMCTS(duration time.Duration) *MCTS {
+	return NewMCTSConfig(ai.Config{
+		Duration:    duration,
+		Seed:        time.Now().UnixNano(),
+		Workers:     runtime.NumCPU(),
+		SolverCells: ai.DefaultSolverCells,
+	})
+}
+
+// NewMCTSConfig returns a player searching with the configuration,
+// it always plays the same moves in the same positions when the configuration has no duration
+func NewMCTSConfig(config ai.Config) *MCTS {
+	return &MCTS{config: config, searcher: ai.NewSearcher(config)}
+}
+
+func (m *MCTS) Name() string {
+	return fmt.Sprintf("MCTS %v", m.config)
+}
+
+func (m *MCTS) Move(ctx context.Context, game *engine.Game) (engine.Move, error) {
+	if err := ctx.Err(); err != nil {
+		return engine.NoMove, err
+	}
+	if game.IsOver() {
+		return engine.NoMove, errNoMove
+	}
+	m.searchMu.Lock()
+	result := m.searcher.Search(ctx, game)
+	m.searchMu.Unlock()
+
+	m.mu.Lock()
+	m.stats = Stats{
+		Simulations:        result.Simulations,
+		WinProbability:     result.WinProbability,
+		PrincipalVariation: result.PrincipalVariation,
+		Candidates:         result.Candidates,
+		Depth:              result.Depth,
+		NodesPerSecond:     result.NodesPerSecond(),
+	}
+	m.mu.Unlock()
+	return result.Move, ctx.Err()
+}
+
+func (m *MCTS) Ponder(ctx context.Context, game *engine.Game) {
+	m.searchMu.Lock()
+	defer m.searchMu.Unlock()
+	m.searcher.Ponder(ctx, game)
+}
+
+func (m *MCTS) LastStats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
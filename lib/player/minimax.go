@@ -0,0 +1,32 @@
+package player
+
+import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"context"
+	"fmt"
+)
+
+// Minimax plays the move found by the alpha-beta search of the ai package
+type Minimax struct {
+	config ai.MinimaxConfig // budget of the search
+}
+
+func NewMinimax(config ai.MinimaxConfig) *Minimax {
+	return &Minimax{config: config}
+}
+
+func (m *Minimax) Name() string {
+	return fmt.Sprintf("Minimax %v", m.config)
+}
+
+func (m *Minimax) Move(ctx context.Context, game *engine.Game) (engine.Move, error) {
+	if err := ctx.Err(); err != nil {
+		return engine.NoMove, err
+	}
+	if game.IsOver() {
+		return engine.NoMove, errNoMove
+	}
+	result := ai.MinimaxSearch(ctx, game, m.config)
+	return result.Move, ctx.Err()
+}
@@ -0,0 +1,60 @@
+// Package player defines who chooses the moves of a game: a human, the Monte Carlo AI, a random player
+// or an external program, and drives games between any two of them.
+package player
+
+import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"context"
+	"fmt"
+)
+
+// Player chooses the moves of one side of a game
+type Player interface {
+	// Name describes the player, it is written in the game records
+	Name() string
+	// Move returns the move to play in the position, or the error of the context when it is cancelled first.
+	// The game belongs to the player during the call and can be modified.
+	Move(ctx context.Context, game *engine.Game) (engine.Move, error)
+}
+
+// Stats describes the search of the last move of a computer player
+type Stats struct {
+	Simulations        int            // number of games simulated
+	WinProbability     float64        // estimated probability of winning with the move
+	PrincipalVariation []engine.Move  // line expected after the move, starting with it
+	Candidates         []ai.MoveStats // most visited moves, best first
+	Depth              int            // deepest position reached by the search, in plies
+	NodesPerSecond     float64        // speed of the search
+}
+
+// StatsReporter is implemented by the players giving statistics on their last move
+type StatsReporter interface {
+	LastStats() Stats
+}
+
+// Ponderer is implemented by the players able to think during the turn of their opponent
+type Ponderer interface {
+	// Ponder searches the game, where the opponent is to move, until the context is cancelled.
+	// The next call to Move waits for the end of the pondering.
+	Ponder(ctx context.Context, game *engine.Game)
+}
+
+// PlayGame lets the two players play the game until its end and returns the winner, NONE for a draw.
+// It returns an error if a player fails to give a move or gives an illegal one.
+func PlayGame(ctx context.Context, game *engine.Game, playerO, playerX Player) (engine.GameSymbol, error) {
+	for !game.IsOver() {
+		current := playerO
+		if game.Playing() == engine.PLAYER2 {
+			current = playerX
+		}
+		move, err := current.Move(ctx, game.Clone())
+		if err != nil {
+			return engine.EMPTY, fmt.Errorf("%s: %w", current.Name(), err)
+		}
+		if err := game.Play(move); err != nil {
+			return engine.EMPTY, fmt.Errorf("%s: %w", current.Name(), err)
+		}
+	}
+	return game.Winner(), nil
+}
@@ -0,0 +1,175 @@
+package player
+
+import (
+	"GoTicTacToe/lib/ai"
+	"GoTicTacToe/lib/engine"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlayGame(t *testing.T) {
+	winner, err := PlayGame(context.Background(), engine.NewGame(engine.PLAYER1), NewRandom(1), First{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if winner == engine.EMPTY {
+		t.Errorf("Expected the game to be finished")
+	}
+}
+
+func TestAIWinAgainstRandom(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		mcts := NewMCTSConfig(ai.Config{Iterations: 5000, Seed: int64(i)})
+		winner, err := PlayGame(context.Background(), engine.NewGame(engine.PLAYER1), mcts, NewRandom(int64(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if winner != engine.PLAYER1 {
+			t.Errorf("AI lost against random")
+		}
+	}
+}
+
+func TestMinimaxWinAgainstRandom(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		minimax := NewMinimax(ai.MinimaxConfig{Depth: 3})
+		winner, err := PlayGame(context.Background(), engine.NewGame(engine.PLAYER1), NewRandom(int64(i)), minimax)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if winner != engine.PLAYER2 {
+			t.Errorf("Minimax lost against random")
+		}
+	}
+}
+
+func TestAIPlaysO(t *testing.T) {
+	ai := NewMCTS(100 * time.Millisecond)
+	winner, err := PlayGame(context.Background(), engine.NewGame(engine.PLAYER1), First{}, ai)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if winner != engine.PLAYER2 {
+		t.Errorf("AI lost against first move player")
+	}
+	// the winning move may be proven without simulations
+	if len(ai.LastStats().PrincipalVariation) == 0 {
+		t.Errorf("Expected statistics on the last move")
+	}
+}
+
+func TestMCTSCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := NewMCTS(time.Minute).Move(ctx, engine.NewGame(engine.PLAYER1))
+	if !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > time.Second {
+		t.Errorf("Expected the search to stop with the context, got %v after %v", err, time.Since(start))
+	}
+}
+
+func TestMCTSPonder(t *testing.T) {
+	mcts := NewMCTSConfig(ai.Config{Iterations: 1000, Seed: 1})
+	game := engine.NewGame(engine.PLAYER1)
+	move, _ := engine.ParseMove("e5")
+	if err := game.Play(move); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		mcts.Ponder(ctx, game.Clone())
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-done
+
+	move, _ = engine.ParseMove("e1")
+	if err := game.Play(move); err != nil {
+		t.Fatal(err)
+	}
+	move, err := mcts.Move(context.Background(), game.Clone())
+	if err != nil || game.Play(move) != nil {
+		t.Errorf("Expected a legal move after pondering, got %v %v", move, err)
+	}
+}
+
+// illegal always plays the center cell
+type illegal struct{}
+
+func (illegal) Name() string {
+	return "Illegal"
+}
+
+func (illegal) Move(context.Context, *engine.Game) (engine.Move, error) {
+	return engine.ParseMove("e5")
+}
+
+func TestPlayGameIllegalMove(t *testing.T) {
+	_, err := PlayGame(context.Background(), engine.NewGame(engine.PLAYER1), illegal{}, illegal{})
+	if !errors.Is(err, engine.ErrWrongBoard) && !errors.Is(err, engine.ErrOccupied) {
+		t.Errorf("Expected an illegal move, got %v", err)
+	}
+}
+
+func TestHuman(t *testing.T) {
+	human := NewHuman("Alice")
+	move, _ := engine.ParseMove("e5")
+	human.Submit(move)
+
+	played, err := human.Move(context.Background(), engine.NewGame(engine.PLAYER1))
+	if err != nil || played != move {
+		t.Errorf("Expected %v, got %v %v", move, played, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := human.Move(ctx, engine.NewGame(engine.PLAYER1)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+// TestHelperProcess is the external program of TestExternal, it plays the first legal move
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		game, err := engine.ParsePosition(strings.TrimPrefix(scanner.Text(), "position "))
+		if err != nil {
+			os.Exit(1)
+		}
+		fmt.Println(game.PossibleMoves()[0])
+	}
+	os.Exit(0)
+}
+
+func TestExternal(t *testing.T) {
+	t.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	external, err := NewExternal(os.Args[0], "-test.run=TestHelperProcess")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer external.Close()
+
+	reference := engine.NewGame(engine.PLAYER1)
+	game := engine.NewGame(engine.PLAYER1)
+	if _, err := PlayGame(context.Background(), game, external, First{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PlayGame(context.Background(), reference, First{}, First{}); err != nil {
+		t.Fatal(err)
+	}
+	if game.String() != reference.String() {
+		t.Errorf("Expected the external program to play the first moves")
+	}
+}
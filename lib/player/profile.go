@@ -0,0 +1,216 @@
+package player
+
+import (
+	"GoTicTacToe/lib/ai"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+)
+
+// Profile is a named difficulty level of the MCTS player: the budget of its search, its parameters
+// and its weaknesses. The seed and the workers of the configuration are set by NewProfileMCTS.
+// The levels with an iteration budget search with a single worker, the way they were calibrated,
+// as the workers share the simulations between independent trees and play weaker with more of them.
+// The levels with a duration budget search on all the processors.
+type Profile struct {
+	Name   string
+	Config ai.Config
+	Score  float64 // share of the points won against ReferenceSpec, measured with cmd/arena, 0 when unknown
+}
+
+// ReferenceSpec is the player the difficulty levels are calibrated against, a small search
+// playing the same on every computer
+const ReferenceSpec = "mcts:iterations=1000"
+
+// DefaultProfiles returns the difficulty levels from the weakest to the strongest.
+// The weak levels have an iteration budget so they play as badly on every computer, and no solver.
+// They play like humans rather than randomly: they draw their moves among the ideas of their search
+// with a temperature, and sometimes overlook the threats of the opponent.
+// The strong levels think for a while and play their simulations with WeightedPolicy,
+// which wins more games than uniform simulations in the same time. They have no score:
+// they win nearly all their games against the reference, which is too weak to tell them apart.
+func DefaultProfiles() []Profile {
+	return []Profile{
+		{Name: "beginner", Score: 0.04, Config: ai.Config{Iterations: 1000, Temperature: 1, ThreatBlindness: 0.5}},
+		{Name: "novice", Score: 0.19, Config: ai.Config{Iterations: 2000, Temperature: 0.5, ThreatBlindness: 0.3}},
+		{Name: "easy", Score: 0.59, Config: ai.Config{Iterations: 4000, Temperature: 0.25, ThreatBlindness: 0.15}},
+		{Name: "casual", Score: 0.88, Config: ai.Config{Iterations: 10000, Temperature: 0.1, ThreatBlindness: 0.05}},
+		{Name: "medium", Config: ai.Config{Duration: time.Second, SolverCells: ai.DefaultSolverCells}},
+		{Name: "hard", Config: ai.Config{
+			Duration:    2 * time.Second,
+			SolverCells: ai.DefaultSolverCells,
+			Policy:      ai.WeightedPolicy{},
+		}},
+		{Name: "expert", Config: ai.Config{
+			Duration:    5 * time.Second,
+			SolverCells: ai.DefaultSolverCells,
+			Policy:      ai.WeightedPolicy{},
+		}},
+	}
+}
+
+// FindProfile returns the profile of the name
+func FindProfile(profiles []Profile, name string) (Profile, bool) {
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return Profile{}, false
+}
+
+// String describes the profile with its measured score
+func (p Profile) String() string {
+	if p.Score == 0 {
+		return p.Name
+	}
+	return fmt.Sprintf("%s (%.0f%% against %s)", p.Name, p.Score*100, ReferenceSpec)
+}
+
+// NewProfileMCTS returns a player searching with the profile
+func NewProfileMCTS(profile Profile, seed int64) *MCTS {
+	config := profile.searchConfig()
+	config.Seed = seed
+	return NewMCTSConfig(config)
+}
+
+// searchConfig returns the configuration of the profile with its workers
+func (p Profile) searchConfig() ai.Config {
+	config := p.Config
+	config.Workers = 1
+	if config.Iterations <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	return config
+}
+
+// profileFile is a profile as written in a profiles file
+type profileFile struct {
+	Name            string   `json:"name"`
+	Iterations      int      `json:"iterations,omitempty"`
+	Duration        string   `json:"duration,omitempty"`    // in the format of time.ParseDuration, like "1.5s"
+	Exploration     *float64 `json:"exploration,omitempty"` // the default of ai.Config when missing
+	DrawReward      *float64 `json:"drawReward,omitempty"`  // the default of ai.Config when missing, a draw is a loss at 0
+	Policy          string   `json:"policy,omitempty"`
+	MistakeRate     float64  `json:"mistakeRate,omitempty"`
+	SolverCells     int      `json:"solverCells,omitempty"`
+	RAVE            float64  `json:"rave,omitempty"`
+	Temperature     float64  `json:"temperature,omitempty"`
+	ThreatBlindness float64  `json:"threatBlindness,omitempty"`
+	Score           float64  `json:"score,omitempty"`
+}
+
+// ReadProfiles returns the default profiles completed by the profiles of the JSON file, a list like
+//
+//	[{"name": "easy", "iterations": 1000, "mistakeRate": 0.2},
+//	 {"name": "blitz", "duration": "300ms", "policy": "tactical", "exploration": 1, "drawReward": 0.5}]
+//
+// A profile of the file replaces the default profile of the same name, the others are added after them.
+// The exploration and the draw reward take their defaults of ai.Config when missing, not when 0:
+// a draw reward of 0 makes a draw count as a loss.
+func ReadProfiles(path string) ([]Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []profileFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	profiles := DefaultProfiles()
+	for _, file := range files {
+		profile, err := file.profile()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		replaced := false
+		for i := range profiles {
+			if profiles[i].Name == profile.Name {
+				profiles[i], replaced = profile, true
+			}
+		}
+		if !replaced {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles, nil
+}
+
+// profile checks the profile of the file and converts it
+func (f profileFile) profile() (Profile, error) {
+	config := ai.Config{
+		Iterations:      f.Iterations,
+		Exploration:     parameter(f.Exploration),
+		DrawReward:      parameter(f.DrawReward),
+		MistakeRate:     f.MistakeRate,
+		SolverCells:     f.SolverCells,
+		RAVE:            f.RAVE,
+		Temperature:     f.Temperature,
+		ThreatBlindness: f.ThreatBlindness,
+	}
+	if f.Name == "" {
+		return Profile{}, errors.New("profile without name")
+	}
+	if f.Duration != "" {
+		duration, err := time.ParseDuration(f.Duration)
+		if err != nil {
+			return Profile{}, fmt.Errorf("profile %s: %w", f.Name, err)
+		}
+		config.Duration = duration
+	}
+	if config.Iterations <= 0 && config.Duration <= 0 {
+		return Profile{}, fmt.Errorf("profile %s: no iterations nor duration", f.Name)
+	}
+	if f.Policy != "" {
+		policy, err := ai.ParsePolicy(f.Policy)
+		if err != nil {
+			return Profile{}, fmt.Errorf("profile %s: %w", f.Name, err)
+		}
+		config.Policy = policy
+	}
+	if err := checkConfig(config); err != nil {
+		return Profile{}, fmt.Errorf("profile %s: %w", f.Name, err)
+	}
+	return Profile{Name: f.Name, Config: config, Score: f.Score}, nil
+}
+
+// parameter converts a parameter of the file to the parameter of ai.Config, set when it is in the file
+func parameter(value *float64) ai.Parameter {
+	if value == nil {
+		return ai.Parameter{}
+	}
+	return ai.SetTo(*value)
+}
+
+// checkConfig returns why the parameters of a profile or of a specification are out of their range,
+// nil when they are all valid
+func checkConfig(config ai.Config) error {
+	for _, setting := range []struct {
+		name  string
+		value float64
+	}{
+		{"exploration", config.Exploration.Value},
+		{"rave", config.RAVE},
+		{"temperature", config.Temperature},
+	} {
+		if setting.value < 0 {
+			return fmt.Errorf("negative %s %g", setting.name, setting.value)
+		}
+	}
+	for _, rate := range []struct {
+		name  string
+		value float64
+	}{
+		{"draw reward", config.DrawReward.Value},
+		{"mistake rate", config.MistakeRate},
+		{"threat blindness", config.ThreatBlindness},
+	} {
+		if rate.value < 0 || rate.value > 1 {
+			return fmt.Errorf("%s %g is not between 0 and 1", rate.name, rate.value)
+		}
+	}
+	return nil
+}
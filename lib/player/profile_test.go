@@ -0,0 +1,81 @@
+package player
+
+import (
+	"GoTicTacToe/lib/ai"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestReadProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	data := `[{"name": "easy", "iterations": 100, "mistakeRate": 0.5},
+		{"name": "pessimist", "iterations": 100, "drawReward": 0, "exploration": 0},
+		{"name": "blitz", "duration": "300ms", "policy": "tactical", "exploration": 1, "drawReward": 0.5}]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := ReadProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != len(DefaultProfiles())+2 {
+		t.Fatalf("Expected the new profiles after the default ones, got %d profiles", len(profiles))
+	}
+	if easy, _ := FindProfile(profiles, "easy"); easy.Config != (ai.Config{Iterations: 100, MistakeRate: 0.5}) {
+		t.Errorf("Expected easy to be replaced, got %+v", easy.Config)
+	}
+	pessimist, _ := FindProfile(profiles, "pessimist")
+	if pessimist.Config != (ai.Config{Iterations: 100, DrawReward: ai.SetTo(0), Exploration: ai.SetTo(0)}) {
+		t.Errorf("Expected a draw reward and an exploration of 0 to be kept, got %+v", pessimist.Config)
+	}
+	expected := ai.Config{Duration: 300 * time.Millisecond, Policy: ai.TacticalPolicy{}, Exploration: ai.SetTo(1), DrawReward: ai.SetTo(0.5)}
+	if blitz := profiles[len(profiles)-1]; blitz.Name != "blitz" || blitz.Config != expected {
+		t.Errorf("Unexpected profile %+v", blitz)
+	}
+}
+
+func TestReadProfilesErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"name": "easy"}`,
+		`[{"iterations": 100}]`,
+		`[{"name": "lazy"}]`,
+		`[{"name": "slow", "duration": "forever"}]`,
+		`[{"name": "smart", "iterations": 100, "policy": "smart"}]`,
+		`[{"name": "clumsy", "iterations": 100, "mistakeRate": 2}]`,
+		`[{"name": "gloomy", "iterations": 100, "drawReward": -0.5}]`,
+		`[{"name": "stubborn", "iterations": 100, "exploration": -1}]`,
+		`[{"name": "eager", "iterations": 100, "drawReward": 1.5}]`,
+		`[{"name": "cold", "iterations": 100, "temperature": -1}]`,
+		`[{"name": "hasty", "iterations": 100, "rave": -300}]`,
+	} {
+		path := filepath.Join(t.TempDir(), "profiles.json")
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadProfiles(path); err == nil {
+			t.Errorf("Expected %s to be refused", data)
+		}
+	}
+}
+
+func TestProfileWorkers(t *testing.T) {
+	for _, profile := range DefaultProfiles() {
+		config := NewProfileMCTS(profile, 1).config
+		if config.Iterations > 0 && config.Workers != 1 {
+			t.Errorf("Expected profile %s to search with the single worker of its calibration, got %d", profile.Name, config.Workers)
+		}
+		if config.Iterations == 0 && config.Workers != runtime.NumCPU() {
+			t.Errorf("Expected profile %s to search on all the processors, got %d", profile.Name, config.Workers)
+		}
+		p, err := Parse("mcts:profile="+profile.Name, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed := p.(*MCTS).config; parsed != config {
+			t.Errorf("Expected the specification of profile %s to play the same search, got %+v", profile.Name, parsed)
+		}
+	}
+}
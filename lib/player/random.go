@@ -0,0 +1,46 @@
+package player
+
+import (
+	"GoTicTacToe/lib/engine"
+	"context"
+	"errors"
+	"math/rand"
+)
+
+var errNoMove = errors.New("no legal move")
+
+// Random plays one of the legal moves uniformly at random
+type Random struct {
+	rng *rand.Rand
+}
+
+func NewRandom(seed int64) *Random {
+	return &Random{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (r *Random) Name() string {
+	return "Random"
+}
+
+func (r *Random) Move(_ context.Context, game *engine.Game) (engine.Move, error) {
+	moves := game.PossibleMoves()
+	if len(moves) == 0 {
+		return engine.NoMove, errNoMove
+	}
+	return moves[r.rng.Intn(len(moves))], nil
+}
+
+// First always plays the first legal move, a predictable opponent for tests
+type First struct{}
+
+func (First) Name() string {
+	return "First"
+}
+
+func (First) Move(_ context.Context, game *engine.Game) (engine.Move, error) {
+	moves := game.PossibleMoves()
+	if len(moves) == 0 {
+		return engine.NoMove, errNoMove
+	}
+	return moves[0], nil
+}
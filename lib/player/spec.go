@@ -0,0 +1,186 @@
+package player
+
+import (
+	"GoTicTacToe/lib/ai"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSpec is returned by Parse, wrapped with the reason of the refusal
+var ErrInvalidSpec = errors.New("invalid player specification")
+
+// Parse creates a computer player from a specification "engine" or "engine:option=value,option=value":
+//
+//	mcts:iterations=5000,duration=1s,seed=1,workers=4,solver=20,policy=tactical,rave=300
+//	mcts:profile=easy,exploration=1,draw=0.5,mistakes=0.1,temperature=0.5,blind=0.3
+//	minimax:depth=4,duration=1s
+//	random:seed=1
+//	first
+//
+// The options of mcts change the configuration of its profile, one of DefaultProfiles searching with the workers
+// of NewProfileMCTS, when it has one.
+// mcts and minimax think for 1s per move when their specification has no budget.
+// An exploration or a draw of 0 sets the parameter to 0, not to its default.
+// The parameters are checked like those of ReadProfiles.
+// The seed is used by the engines whose specification has none.
+func Parse(spec string, seed int64) (Player, error) {
+	name, list, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	options := map[string]string{}
+	if list != "" {
+		for _, option := range strings.Split(list, ",") {
+			key, value, found := strings.Cut(option, "=")
+			if !found {
+				return nil, fmt.Errorf("%w: option %q without value", ErrInvalidSpec, option)
+			}
+			options[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	o := specOptions{options: options}
+	if _, ok := options["seed"]; ok {
+		seed = o.int64("seed")
+	}
+
+	var p Player
+	switch name {
+	case "mcts":
+		var config ai.Config
+		if name, ok := o.value("profile"); ok {
+			profile, found := FindProfile(DefaultProfiles(), name)
+			if !found {
+				o.err = fmt.Errorf("unknown profile %q", name)
+			}
+			config = profile.searchConfig()
+		}
+		config.Seed = seed
+		o.setInt(&config.Iterations, "iterations")
+		o.setDuration(&config.Duration, "duration")
+		o.setInt(&config.Workers, "workers")
+		o.setInt(&config.SolverCells, "solver")
+		o.setFloat(&config.RAVE, "rave")
+		o.setParameter(&config.Exploration, "exploration")
+		o.setParameter(&config.DrawReward, "draw")
+		o.setFloat(&config.MistakeRate, "mistakes")
+		o.setFloat(&config.Temperature, "temperature")
+		o.setFloat(&config.ThreatBlindness, "blind")
+		if policy, ok := o.value("policy"); ok {
+			config.Policy, o.err = ai.ParsePolicy(policy)
+		}
+		if config.Iterations == 0 && config.Duration == 0 {
+			config.Duration = time.Second
+		}
+		if o.err == nil {
+			o.err = checkConfig(config)
+		}
+		p = NewMCTSConfig(config)
+	case "minimax":
+		config := ai.MinimaxConfig{Depth: o.int("depth"), Duration: o.duration("duration")}
+		if config.Depth == 0 && config.Duration == 0 {
+			config.Duration = time.Second
+		}
+		p = NewMinimax(config)
+	case "random":
+		p = NewRandom(seed)
+	case "first":
+		p = First{}
+	default:
+		return nil, fmt.Errorf("%w: unknown engine %q", ErrInvalidSpec, name)
+	}
+	if o.err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidSpec, spec, o.err)
+	}
+	for key := range options {
+		if !o.used[key] {
+			return nil, fmt.Errorf("%w: unknown option %q of %s", ErrInvalidSpec, key, name)
+		}
+	}
+	return p, nil
+}
+
+// specOptions reads the options of a specification, keeping the first error
+type specOptions struct {
+	options map[string]string
+	used    map[string]bool
+	err     error
+}
+
+func (o *specOptions) value(key string) (string, bool) {
+	if o.used == nil {
+		o.used = map[string]bool{}
+	}
+	o.used[key] = true
+	value, ok := o.options[key]
+	return value, ok && o.err == nil
+}
+
+func (o *specOptions) int(key string) int {
+	value, ok := o.value(key)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		o.err = err
+	}
+	return n
+}
+
+func (o *specOptions) int64(key string) int64 {
+	value, ok := o.value(key)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		o.err = err
+	}
+	return n
+}
+
+// setInt, setFloat and setDuration replace the value by the option when it is given
+
+func (o *specOptions) setInt(n *int, key string) {
+	if _, ok := o.options[key]; ok {
+		*n = o.int(key)
+	}
+}
+
+func (o *specOptions) setFloat(f *float64, key string) {
+	value, ok := o.value(key)
+	if !ok {
+		return
+	}
+	var err error
+	if *f, err = strconv.ParseFloat(value, 64); err != nil {
+		o.err = err
+	}
+}
+
+// setParameter sets a parameter of ai.Config when the option is given, it keeps its default otherwise
+func (o *specOptions) setParameter(p *ai.Parameter, key string) {
+	if _, ok := o.options[key]; ok {
+		var value float64
+		o.setFloat(&value, key)
+		*p = ai.SetTo(value)
+	}
+}
+
+func (o *specOptions) setDuration(d *time.Duration, key string) {
+	if _, ok := o.options[key]; ok {
+		*d = o.duration(key)
+	}
+}
+
+func (o *specOptions) duration(key string) time.Duration {
+	value, ok := o.value(key)
+	if !ok {
+		return 0
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		o.err = err
+	}
+	return d
+}
@@ -0,0 +1,67 @@
+package player
+
+import (
+	"GoTicTacToe/lib/ai"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		"mcts":                                 "MCTS 1s",
+		"mcts:iterations=500,policy=tactical":  "MCTS 500 simulations tactical",
+		"mcts:duration=2s,workers=2,solver=20": "MCTS 2s",
+		"mcts:iterations=500,rave=300":         "MCTS 500 simulations rave",
+		"mcts:profile=easy,mistakes=0.1":       "MCTS 4000 simulations 10% mistakes temperature 0.25 15% blind",
+		"mcts:profile=beginner,blind=0":        "MCTS 1000 simulations temperature 1",
+		"minimax:depth=4":                      "Minimax depth 4",
+		"minimax":                              "Minimax 1s",
+		"random:seed=3":                        "Random",
+		" first ":                              "First",
+	}
+	for spec, name := range tests {
+		p, err := Parse(spec, 1)
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+		} else if p.Name() != name {
+			t.Errorf("Expected %q for %s, got %q", name, spec, p.Name())
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"alphazero",
+		"mcts:iterations",
+		"mcts:iterations=many",
+		"mcts:policy=smart",
+		"mcts:rave=high",
+		"mcts:profile=grandmaster",
+		"mcts:exploration=-1",
+		"mcts:draw=-0.5",
+		"mcts:draw=1.5",
+		"mcts:rave=-300",
+		"mcts:mistakes=-0.1",
+		"mcts:mistakes=2",
+		"mcts:temperature=-1",
+		"mcts:blind=-0.3",
+		"minimax:duration=soon",
+		"minimax:iterations=100",
+		"first:depth=1",
+	} {
+		if _, err := Parse(spec, 1); !errors.Is(err, ErrInvalidSpec) {
+			t.Errorf("Expected %s to be refused, got %v", spec, err)
+		}
+	}
+}
+
+func TestParseZeroParameters(t *testing.T) {
+	p, err := Parse("mcts:iterations=100,draw=0,exploration=0", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config := p.(*MCTS).config; config.DrawReward != ai.SetTo(0) || config.Exploration != ai.SetTo(0) {
+		t.Errorf("Expected a draw reward and an exploration of 0 to be kept, got %+v", config)
+	}
+}